@@ -1,20 +1,74 @@
 package main
 
 import (
+	"fmt"
 	"log/slog"
 	"love-signal-users/internal/app"
 	"love-signal-users/internal/config"
 	"love-signal-users/pkg/logger"
+	"love-signal-users/pkg/logger/handlers/sampling"
+	"love-signal-users/pkg/logger/redact"
+	"os"
 )
 
 func main() {
-	cfg := config.MustLoad()
+	flags := config.ParseFlags()
 
-	log := logger.SetupLogger(cfg.Env)
+	cfg, err := config.Load(flags)
+	if flags.ValidateConfig {
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "config is invalid:\n%v\n", err)
+			os.Exit(1)
+		}
+
+		fmt.Println("config is valid")
+		return
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "cannot load config:\n%v\n", err)
+		os.Exit(1)
+	}
+
+	if flags.PrintConfig {
+		if err = config.Print(os.Stdout, cfg); err != nil {
+			fmt.Fprintf(os.Stderr, "cannot print config: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	redaction := redact.New(
+		redact.WithMaskedKeys(cfg.Log.Redaction.MaskedKeys...),
+		redact.WithHashedKeys(cfg.Log.Redaction.HashedKeys...),
+		redact.WithHashSalt(cfg.Log.Redaction.HashSalt),
+	)
+
+	logLevels := logger.NewLevels()
+	logOpts := []logger.Option{logger.WithReplaceAttr(redaction.ReplaceAttr), logger.WithLevels(logLevels)}
+	if cfg.Log.Sampling.Enabled {
+		samplingOpts, err := logSampling(cfg.Log.Sampling)
+		if err != nil {
+			panic("cannot set up log sampling: " + err.Error())
+		}
+		logOpts = append(logOpts, logger.WithSampling(samplingOpts...))
+	}
+
+	log, closeLog, err := logger.Setup(cfg.Env, logSinks(cfg.Log.Sinks), logOpts...)
+	if err != nil {
+		panic("cannot set up logger: " + err.Error())
+	}
+	defer func() {
+		_ = closeLog()
+	}()
 
 	log.Info("starting application", slog.Any("config", cfg))
 
-	application := app.New(log, cfg)
+	// The config is reloaded from the same files, environment variables and overrides.
+	loadConfig := func() (*config.Config, error) {
+		return config.Load(flags)
+	}
+
+	application := app.New(log, cfg, loadConfig, logLevels)
 
 	go func() {
 		application.Start()
@@ -23,3 +77,34 @@ func main() {
 	application.GracefulStop()
 	log.Info("application stopped")
 }
+
+func logSinks(sinksConfig []config.LogSinkConfig) []logger.Sink {
+	sinks := make([]logger.Sink, 0, len(sinksConfig))
+	for _, sink := range sinksConfig {
+		sinks = append(sinks, logger.Sink{
+			Type:       sink.Type,
+			Level:      sink.Level,
+			Path:       sink.Path,
+			MaxSizeMB:  sink.MaxSizeMB,
+			MaxAgeDays: sink.MaxAgeDays,
+			MaxBackups: sink.MaxBackups,
+			Compress:   sink.Compress,
+		})
+	}
+
+	return sinks
+}
+
+func logSampling(samplingConfig config.LogSamplingConfig) ([]sampling.Option, error) {
+	opts := []sampling.Option{sampling.WithWindow(samplingConfig.Window)}
+	for levelName, limit := range samplingConfig.Levels {
+		level, err := logger.ParseLevel(levelName)
+		if err != nil {
+			return nil, err
+		}
+
+		opts = append(opts, sampling.WithLimit(level, sampling.Limit{Burst: limit.Burst, Rate: limit.Rate}))
+	}
+
+	return opts, nil
+}
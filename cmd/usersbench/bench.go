@@ -0,0 +1,123 @@
+package main
+
+import (
+	"context"
+	lsuserspb "github.com/p1xray/love-signal-protos/gen/go/users"
+	"golang.org/x/time/rate"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"math/rand/v2"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// codeUnsuccessful is the code of FollowUser and UnfollowUser calls answered without an error but without success.
+const codeUnsuccessful = "Unsuccessful"
+
+// bench drives the server with calls picked from the mix.
+type bench struct {
+	users       lsuserspb.UsersClient
+	targets     targets
+	mix         mix
+	callTimeout time.Duration
+	// limiter limits the rate of all the calls; nil means no limit.
+	limiter *rate.Limiter
+
+	// started and completed are the numbers of calls, for limiting them and for progress.
+	started   atomic.Int64
+	completed atomic.Int64
+}
+
+// run calls the server from the workers until the context is done or maxCalls calls are made, if it is positive.
+// Calls interrupted by the end of the context are not recorded.
+func (b *bench) run(ctx context.Context, workers int, maxCalls int64, seed uint64) []*recorder {
+	recorders := make([]*recorder, workers)
+	var wg sync.WaitGroup
+	for i := range workers {
+		recorders[i] = newRecorder()
+		r := rand.New(rand.NewPCG(seed, uint64(i)))
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			b.work(ctx, r, recorders[i], maxCalls)
+		}()
+	}
+	wg.Wait()
+
+	return recorders
+}
+
+func (b *bench) work(ctx context.Context, r *rand.Rand, rec *recorder, maxCalls int64) {
+	for ctx.Err() == nil {
+		if maxCalls > 0 && b.started.Add(1) > maxCalls {
+			return
+		}
+		if b.limiter != nil && b.limiter.Wait(ctx) != nil {
+			return
+		}
+
+		method := b.mix.pick(r)
+		start := time.Now()
+		code := b.call(ctx, r, method)
+		latency := time.Since(start)
+		if ctx.Err() != nil {
+			return
+		}
+
+		rec.add(method, latency, code)
+		b.completed.Add(1)
+	}
+}
+
+// call makes a call of the method and returns its status code.
+func (b *bench) call(ctx context.Context, r *rand.Rand, method string) string {
+	ctx, cancel := context.WithTimeout(ctx, b.callTimeout)
+	defer cancel()
+
+	var success bool
+	var err error
+	switch method {
+	case methodGetUserData:
+		_, err = b.users.GetUserData(ctx, &lsuserspb.GetUserDataRequest{UserId: b.targets.user(r).ID})
+		success = true
+	case methodGetUserDataByExternalID:
+		_, err = b.users.GetUserDataByExternalId(ctx, &lsuserspb.GetUserDataByExternalIdRequest{
+			UserExternalId: b.targets.user(r).ExternalID,
+		})
+		success = true
+	case methodGetFollowedUsers:
+		var resp *lsuserspb.FollowedUsersResponse
+		resp, err = b.users.GetFollowedUsers(ctx, &lsuserspb.GetFollowedUsersRequest{UserId: b.targets.user(r).ID})
+		if err == nil {
+			ids := make([]int64, 0, len(resp.GetUsers()))
+			for _, u := range resp.GetUsers() {
+				ids = append(ids, u.GetFollowLinkId())
+			}
+			b.targets.links.add(ids)
+		}
+		success = true
+	case methodFollowUser:
+		following, followed := b.targets.userPair(r)
+		var resp *lsuserspb.FollowUserResponse
+		resp, err = b.users.FollowUser(ctx, &lsuserspb.FollowUserRequest{
+			UserId:         following.ID,
+			UserIdToFollow: followed.ID,
+		})
+		success = resp.GetSuccess()
+	case methodUnfollowUser:
+		var resp *lsuserspb.UnfollowUserResponse
+		resp, err = b.users.UnfollowUser(ctx, &lsuserspb.UnfollowUserRequest{FollowLinkId: b.targets.links.take(r)})
+		success = resp.GetSuccess()
+	}
+
+	if err != nil {
+		return status.Code(err).String()
+	}
+	if !success {
+		return codeUnsuccessful
+	}
+
+	return codes.OK.String()
+}
@@ -0,0 +1,129 @@
+// Command usersbench drives a running users service with a mix of calls and reports
+// the throughput, the latency percentiles and the distribution of status codes.
+// User and follow link IDs are sampled from the seeded database the server runs on.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	lsuserspb "github.com/p1xray/love-signal-protos/gen/go/users"
+	"golang.org/x/time/rate"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+)
+
+// settings are the flags of the run.
+type settings struct {
+	address        string
+	storagePath    string
+	mix            string
+	duration       time.Duration
+	maxCalls       int64
+	concurrency    int
+	rate           float64
+	callTimeout    time.Duration
+	usersLimit     int
+	linksLimit     int
+	seed           uint64
+	output         string
+	reportInterval time.Duration
+}
+
+func main() {
+	var s settings
+	flag.StringVar(&s.address, "address", "localhost:6005", "gRPC address of the server")
+	flag.StringVar(&s.storagePath, "storage-path", "./storage/users.db", "path to storage of the server to sample IDs from")
+	flag.StringVar(&s.mix, "mix", defaultMix, "weights of the called methods")
+	flag.DurationVar(&s.duration, "duration", 30*time.Second, "duration of the run")
+	flag.Int64Var(&s.maxCalls, "calls", 0, "number of calls after which the run stops; 0 means no limit")
+	flag.IntVar(&s.concurrency, "concurrency", 16, "number of concurrent callers")
+	flag.Float64Var(&s.rate, "rate", 0, "maximum calls per second of all callers; 0 means no limit")
+	flag.DurationVar(&s.callTimeout, "timeout", 5*time.Second, "timeout of a call")
+	flag.IntVar(&s.usersLimit, "users", 100000, "number of users sampled from storage")
+	flag.IntVar(&s.linksLimit, "links", 100000, "number of follow links sampled from storage for unfollowing")
+	flag.Uint64Var(&s.seed, "seed", 1, "seed of the picked methods and IDs")
+	flag.StringVar(&s.output, "output", formatTable, "output format: table or json")
+	flag.DurationVar(&s.reportInterval, "report-interval", 5*time.Second, "interval of progress on stderr; 0 disables it")
+	flag.Parse()
+
+	if err := run(s); err != nil {
+		fmt.Fprintf(os.Stderr, "usersbench: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(s settings) error {
+	m, err := parseMix(s.mix)
+	if err != nil {
+		return err
+	}
+	if s.output != formatTable && s.output != formatJSON {
+		return fmt.Errorf("unknown output format %q", s.output)
+	}
+	if s.concurrency <= 0 || s.duration <= 0 || s.callTimeout <= 0 || s.usersLimit <= 0 || s.linksLimit <= 0 {
+		return errors.New("concurrency, duration, timeout, users and links must be positive")
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	t, err := loadTargets(ctx, s.storagePath, s.usersLimit, s.linksLimit)
+	if err != nil {
+		return err
+	}
+
+	conn, err := grpc.NewClient(s.address, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = conn.Close()
+	}()
+
+	b := &bench{
+		users:       lsuserspb.NewUsersClient(conn),
+		targets:     t,
+		mix:         m,
+		callTimeout: s.callTimeout,
+	}
+	if s.rate > 0 {
+		b.limiter = rate.NewLimiter(rate.Limit(s.rate), 1)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, s.duration)
+	defer cancel()
+
+	start := time.Now()
+	if s.reportInterval > 0 {
+		go reportProgress(ctx, b, start, s.reportInterval)
+	}
+
+	recorders := b.run(ctx, s.concurrency, s.maxCalls, s.seed)
+
+	return newReport(recorders, time.Since(start)).write(os.Stdout, s.output)
+}
+
+// reportProgress writes the number of completed calls and the throughput of the last interval to stderr.
+func reportProgress(ctx context.Context, b *bench, start time.Time, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	var last int64
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			completed := b.completed.Load()
+			fmt.Fprintf(os.Stderr, "%s: %d calls, %.1f calls/s\n",
+				time.Since(start).Round(time.Second), completed, float64(completed-last)/interval.Seconds())
+			last = completed
+		}
+	}
+}
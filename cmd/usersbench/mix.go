@@ -0,0 +1,85 @@
+package main
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Methods of the users service driven by the tool.
+const (
+	methodGetUserData             = "GetUserData"
+	methodGetUserDataByExternalID = "GetUserDataByExternalId"
+	methodGetFollowedUsers        = "GetFollowedUsers"
+	methodFollowUser              = "FollowUser"
+	methodUnfollowUser            = "UnfollowUser"
+)
+
+var methods = []string{
+	methodGetUserData,
+	methodGetUserDataByExternalID,
+	methodGetFollowedUsers,
+	methodFollowUser,
+	methodUnfollowUser,
+}
+
+// defaultMix is mostly reads, with follows slightly outnumbering unfollows, as in production.
+const defaultMix = "GetUserData=35,GetUserDataByExternalId=15,GetFollowedUsers=35,FollowUser=10,UnfollowUser=5"
+
+// mix is the weighted set of methods the calls are picked from.
+type mix struct {
+	methods []string
+	// cumulative are the cumulative weights of the methods.
+	cumulative []int
+}
+
+// parseMix parses the mix of the form "Method=weight,...". Methods that are not listed are not called.
+func parseMix(value string) (mix, error) {
+	var m mix
+	total := 0
+	seen := make(map[string]bool)
+	for _, part := range strings.Split(value, ",") {
+		method, weightValue, ok := strings.Cut(strings.TrimSpace(part), "=")
+		if !ok {
+			return mix{}, fmt.Errorf("invalid mix entry %q: expected Method=weight", part)
+		}
+		if !slices.Contains(methods, method) {
+			return mix{}, fmt.Errorf("unknown method %q in mix: expected one of %s", method, strings.Join(methods, ", "))
+		}
+		if seen[method] {
+			return mix{}, fmt.Errorf("method %q is repeated in mix", method)
+		}
+		seen[method] = true
+
+		weight, err := strconv.Atoi(weightValue)
+		if err != nil || weight < 0 {
+			return mix{}, fmt.Errorf("invalid weight %q of %s: expected a non-negative integer", weightValue, method)
+		}
+		if weight == 0 {
+			continue
+		}
+
+		total += weight
+		m.methods = append(m.methods, method)
+		m.cumulative = append(m.cumulative, total)
+	}
+	if total == 0 {
+		return mix{}, fmt.Errorf("mix has no methods with positive weight")
+	}
+
+	return m, nil
+}
+
+// pick returns a random method with the probability of its weight.
+func (m mix) pick(r *rand.Rand) string {
+	x := r.IntN(m.cumulative[len(m.cumulative)-1])
+	for i, c := range m.cumulative {
+		if x < c {
+			return m.methods[i]
+		}
+	}
+
+	return m.methods[len(m.methods)-1]
+}
@@ -0,0 +1,200 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"google.golang.org/grpc/codes"
+	"io"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// Output formats.
+const (
+	formatTable = "table"
+	formatJSON  = "json"
+)
+
+// recorder records the calls of one worker, so that recording needs no locks.
+type recorder struct {
+	methods map[string]*methodCalls
+}
+
+// methodCalls are the latencies and the status codes of the calls of a method.
+type methodCalls struct {
+	latencies []time.Duration
+	codes     map[string]int
+}
+
+func newRecorder() *recorder {
+	return &recorder{methods: make(map[string]*methodCalls)}
+}
+
+func (r *recorder) add(method string, latency time.Duration, code string) {
+	calls, ok := r.methods[method]
+	if !ok {
+		calls = &methodCalls{codes: make(map[string]int)}
+		r.methods[method] = calls
+	}
+
+	calls.latencies = append(calls.latencies, latency)
+	calls.codes[code]++
+}
+
+// report is the result of the run. Latencies are in milliseconds.
+type report struct {
+	DurationSeconds float64        `json:"durationSeconds"`
+	Calls           int            `json:"calls"`
+	Errors          int            `json:"errors"`
+	Throughput      float64        `json:"throughput"`
+	Latency         latencyReport  `json:"latency"`
+	Codes           map[string]int `json:"codes"`
+	Methods         []methodReport `json:"methods"`
+}
+
+type methodReport struct {
+	Method     string         `json:"method"`
+	Calls      int            `json:"calls"`
+	Errors     int            `json:"errors"`
+	Throughput float64        `json:"throughput"`
+	Latency    latencyReport  `json:"latency"`
+	Codes      map[string]int `json:"codes"`
+}
+
+type latencyReport struct {
+	P50  float64 `json:"p50"`
+	P90  float64 `json:"p90"`
+	P99  float64 `json:"p99"`
+	P999 float64 `json:"p999"`
+	Max  float64 `json:"max"`
+}
+
+// newReport merges the records of the workers. Methods are in the order of the methods list.
+func newReport(recorders []*recorder, elapsed time.Duration) report {
+	rep := report{
+		DurationSeconds: elapsed.Seconds(),
+		Codes:           make(map[string]int),
+	}
+
+	var all []time.Duration
+	for _, method := range methods {
+		var latencies []time.Duration
+		methodCodes := make(map[string]int)
+		for _, rec := range recorders {
+			calls, ok := rec.methods[method]
+			if !ok {
+				continue
+			}
+
+			latencies = append(latencies, calls.latencies...)
+			for code, n := range calls.codes {
+				methodCodes[code] += n
+			}
+		}
+		if len(latencies) == 0 {
+			continue
+		}
+
+		errors := 0
+		for code, n := range methodCodes {
+			rep.Codes[code] += n
+			if code != codes.OK.String() {
+				errors += n
+			}
+		}
+
+		rep.Methods = append(rep.Methods, methodReport{
+			Method:     method,
+			Calls:      len(latencies),
+			Errors:     errors,
+			Throughput: float64(len(latencies)) / elapsed.Seconds(),
+			Latency:    newLatencyReport(latencies),
+			Codes:      methodCodes,
+		})
+		rep.Calls += len(latencies)
+		rep.Errors += errors
+		all = append(all, latencies...)
+	}
+
+	rep.Throughput = float64(rep.Calls) / elapsed.Seconds()
+	rep.Latency = newLatencyReport(all)
+
+	return rep
+}
+
+// newLatencyReport returns the percentiles of the latencies, sorting them.
+func newLatencyReport(latencies []time.Duration) latencyReport {
+	if len(latencies) == 0 {
+		return latencyReport{}
+	}
+
+	slices.Sort(latencies)
+	percentile := func(p float64) float64 {
+		i := min(int(p*float64(len(latencies))), len(latencies)-1)
+
+		return milliseconds(latencies[i])
+	}
+
+	return latencyReport{
+		P50:  percentile(0.5),
+		P90:  percentile(0.9),
+		P99:  percentile(0.99),
+		P999: percentile(0.999),
+		Max:  milliseconds(latencies[len(latencies)-1]),
+	}
+}
+
+func milliseconds(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
+
+// write writes the report as indented JSON or as tables of the methods and of the status codes.
+func (r report) write(w io.Writer, format string) error {
+	if format == formatJSON {
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+
+		return encoder.Encode(r)
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(tw, "METHOD\tCALLS\tERRORS\tCALLS/S\tP50 MS\tP90 MS\tP99 MS\tP99.9 MS\tMAX MS\t")
+	for _, m := range r.Methods {
+		writeRow(tw, m.Method, m.Calls, m.Errors, m.Throughput, m.Latency)
+	}
+	writeRow(tw, "total", r.Calls, r.Errors, r.Throughput, r.Latency)
+	fmt.Fprintln(tw)
+
+	fmt.Fprintln(tw, "METHOD\tCODE\tCALLS\tSHARE\t")
+	for _, m := range r.Methods {
+		for _, code := range slices.Sorted(maps.Keys(m.Codes)) {
+			share := float64(m.Codes[code]) / float64(m.Calls) * 100
+			fmt.Fprintf(tw, "%s\t%s\t%d\t%.2f%%\t\n", m.Method, code, m.Codes[code], share)
+		}
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	_, err := fmt.Fprintf(w, "\n%d calls in %.1fs\n", r.Calls, r.DurationSeconds)
+
+	return err
+}
+
+func writeRow(w io.Writer, name string, calls, errors int, throughput float64, latency latencyReport) {
+	cells := []string{
+		name,
+		strconv.Itoa(calls),
+		strconv.Itoa(errors),
+		strconv.FormatFloat(throughput, 'f', 1, 64),
+	}
+	for _, ms := range []float64{latency.P50, latency.P90, latency.P99, latency.P999, latency.Max} {
+		cells = append(cells, strconv.FormatFloat(ms, 'f', 2, 64))
+	}
+
+	fmt.Fprintln(w, strings.Join(cells, "\t")+"\t")
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"love-signal-users/internal/infrastructure/storage/models"
+	"love-signal-users/internal/infrastructure/storage/sqlite"
+	"math/rand/v2"
+	"sync"
+)
+
+// missingFollowLinkID is unfollowed when there are no known follow links left, which measures the not found path.
+const missingFollowLinkID = -1
+
+// targets are the IDs the calls are made with.
+type targets struct {
+	users []models.UserKey
+	links *linkPool
+}
+
+// loadTargets samples the IDs of users and follow links from the seeded database the server runs on.
+func loadTargets(ctx context.Context, storagePath string, usersLimit, linksLimit int) (targets, error) {
+	const op = "usersbench.loadTargets"
+
+	storage, err := sqlite.New(storagePath)
+	if err != nil {
+		return targets{}, fmt.Errorf("%s: %w", op, err)
+	}
+	defer func() {
+		_ = storage.Close()
+	}()
+
+	users, err := storage.SampleUserKeys(ctx, usersLimit)
+	if err != nil {
+		return targets{}, fmt.Errorf("%s: %w", op, err)
+	}
+	if len(users) < 2 {
+		return targets{}, fmt.Errorf("%s: %w", op, errors.New("at least two users are required, seed the database first"))
+	}
+
+	links, err := storage.SampleFollowLinkIDs(ctx, linksLimit)
+	if err != nil {
+		return targets{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return targets{
+		users: users,
+		links: newLinkPool(links, linksLimit),
+	}, nil
+}
+
+func (t targets) user(r *rand.Rand) models.UserKey {
+	return t.users[r.IntN(len(t.users))]
+}
+
+// userPair returns two different users.
+func (t targets) userPair(r *rand.Rand) (models.UserKey, models.UserKey) {
+	i := r.IntN(len(t.users))
+	j := r.IntN(len(t.users) - 1)
+	if j >= i {
+		j++
+	}
+
+	return t.users[i], t.users[j]
+}
+
+// linkPool is the follow links that can be unfollowed. Each link is taken once, as it is removed by the unfollow;
+// the pool is refilled with the links returned by GetFollowedUsers.
+type linkPool struct {
+	mu     sync.Mutex
+	ids    []int64
+	pooled map[int64]struct{}
+	limit  int
+}
+
+func newLinkPool(ids []int64, limit int) *linkPool {
+	p := &linkPool{pooled: make(map[int64]struct{}, limit), limit: limit}
+	p.add(ids)
+
+	return p
+}
+
+// take returns a random link and removes it from the pool, or missingFollowLinkID if the pool is empty.
+func (p *linkPool) take(r *rand.Rand) int64 {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if len(p.ids) == 0 {
+		return missingFollowLinkID
+	}
+
+	i := r.IntN(len(p.ids))
+	id := p.ids[i]
+	p.ids[i] = p.ids[len(p.ids)-1]
+	p.ids = p.ids[:len(p.ids)-1]
+	delete(p.pooled, id)
+
+	return id
+}
+
+// add adds the links that are not in the pool yet while it is below its limit.
+func (p *linkPool) add(ids []int64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, id := range ids {
+		if len(p.ids) >= p.limit {
+			return
+		}
+		if _, ok := p.pooled[id]; ok {
+			continue
+		}
+
+		p.pooled[id] = struct{}{}
+		p.ids = append(p.ids, id)
+	}
+}
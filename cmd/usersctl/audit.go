@@ -0,0 +1,46 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"love-signal-users/pkg/audit"
+	"os"
+	"strconv"
+)
+
+// auditVerifyResult is the result of the verification of the audit log.
+type auditVerifyResult struct {
+	Path    string `json:"path"`
+	Records int    `json:"records"`
+	Status  string `json:"status"`
+}
+
+// auditVerify checks the hash chain of the audit log file. A broken chain fails the command
+// with the line of the first broken record.
+func auditVerify(_ context.Context, _ client, p printer, args []string) error {
+	fs := flag.NewFlagSet("audit verify", flag.ContinueOnError)
+	path := fs.String("path", "", "audit log file")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if *path == "" {
+		return fmt.Errorf("%w: -path is required", errUsage)
+	}
+
+	file, err := os.Open(*path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	records, err := audit.Verify(file)
+	if err != nil {
+		return fmt.Errorf("%s: %d records verified before: %w", *path, records, err)
+	}
+
+	return p.print(auditVerifyResult{Path: *path, Records: records, Status: statusOK},
+		[]string{"PATH", "RECORDS", "STATUS"},
+		[][]string{{*path, strconv.Itoa(records), statusOK}},
+	)
+}
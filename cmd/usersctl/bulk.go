@@ -0,0 +1,328 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"love-signal-users/internal/infrastructure/storage/models"
+	"love-signal-users/internal/infrastructure/storage/sqlite"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+)
+
+// defaultBatchSize is the number of imported rows upserted in one transaction.
+const defaultBatchSize = 1000
+
+// importRecord is a record that is validated and converted to the model M for storage.
+type importRecord[M any] interface {
+	record
+	toModel(now time.Time) (M, error)
+}
+
+// exportSummary is the output of an export.
+type exportSummary struct {
+	File string `json:"file"`
+	Rows int    `json:"rows"`
+}
+
+// importSummary is the output of an import.
+type importSummary struct {
+	File         string `json:"file"`
+	Rows         int    `json:"rows"`
+	Imported     int    `json:"imported"`
+	Rejected     int    `json:"rejected"`
+	RejectedFile string `json:"rejectedFile,omitempty"`
+}
+
+func exportUsers(ctx context.Context, c client, p printer, args []string) error {
+	return exportRows(ctx, c, p, "export users", args, (&userRecord{}).columns(),
+		func(storage *sqlite.Storage, write func(record) error) error {
+			return storage.EachUser(ctx, func(u models.UserWithInterests) error {
+				return write(newUserRecord(u))
+			})
+		})
+}
+
+func exportFollows(ctx context.Context, c client, p printer, args []string) error {
+	return exportRows(ctx, c, p, "export follows", args, (&followRecord{}).columns(),
+		func(storage *sqlite.Storage, write func(record) error) error {
+			return storage.EachExternalFollow(ctx, func(f models.ExternalFollow) error {
+				return write(newFollowRecord(f))
+			})
+		})
+}
+
+func importUsers(ctx context.Context, c client, p printer, args []string) error {
+	return importRows(ctx, c, p, "import users", args,
+		func() importRecord[models.UserWithInterests] { return &userRecord{} },
+		func(storage *sqlite.Storage, users []models.UserWithInterests) ([]int, error) {
+			return storage.UpsertUsers(ctx, users)
+		},
+		"interests are not in the dictionary of interest tags",
+	)
+}
+
+func importFollows(ctx context.Context, c client, p printer, args []string) error {
+	return importRows(ctx, c, p, "import follows", args,
+		func() importRecord[models.ExternalFollow] { return &followRecord{} },
+		func(storage *sqlite.Storage, follows []models.ExternalFollow) ([]int, error) {
+			return storage.UpsertExternalFollows(ctx, follows)
+		},
+		"user with the external ID is not found",
+	)
+}
+
+// exportRows writes the rows passed by each to the file, streaming them from storage.
+func exportRows(
+	ctx context.Context,
+	c client,
+	p printer,
+	command string,
+	args []string,
+	columns []string,
+	each func(storage *sqlite.Storage, write func(record) error) error,
+) error {
+	fs := flag.NewFlagSet(command, flag.ContinueOnError)
+	file := fs.String("file", "", "file to write")
+	format := fs.String("format", "", "file format: jsonl or csv; by default, csv for .csv files and jsonl otherwise")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if *file == "" {
+		return fmt.Errorf("%w: -file is required", errUsage)
+	}
+	fileFormat, err := parseFileFormat(*format, *file)
+	if err != nil {
+		return err
+	}
+
+	out, err := os.Create(*file)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = out.Close()
+	}()
+
+	w := newRowWriter(out, fileFormat, columns)
+	rows := 0
+	err = each(c.(*dbClient).storage, func(r record) error {
+		rows++
+
+		return w.write(r)
+	})
+	if err != nil {
+		return err
+	}
+	if err = w.flush(); err != nil {
+		return err
+	}
+	if err = out.Close(); err != nil {
+		return err
+	}
+
+	return p.print(exportSummary{File: *file, Rows: rows},
+		[]string{"FILE", "ROWS"},
+		[][]string{{*file, strconv.Itoa(rows)}},
+	)
+}
+
+// importRows reads the rows of the file, validates them and upserts them in batches, one transaction each.
+// Invalid rows and the rows upsert reports as skipped are written to the file of rejected rows, the skipped ones
+// with skippedError.
+// If a batch fails, the import stops; the batches before it stay imported.
+func importRows[M any](
+	ctx context.Context,
+	c client,
+	p printer,
+	command string,
+	args []string,
+	newRecord func() importRecord[M],
+	upsert func(storage *sqlite.Storage, batch []M) (skipped []int, err error),
+	skippedError string,
+) error {
+	fs := flag.NewFlagSet(command, flag.ContinueOnError)
+	file := fs.String("file", "", "file to read")
+	format := fs.String("format", "", "file format: jsonl or csv; by default, csv for .csv files and jsonl otherwise")
+	batchSize := fs.Int("batch-size", defaultBatchSize, "number of rows upserted in one transaction")
+	rejectedFile := fs.String("rejected", "", "JSONL file of rejected rows; by default, the file with .rejected.jsonl added")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if *file == "" {
+		return fmt.Errorf("%w: -file is required", errUsage)
+	}
+	if *batchSize <= 0 {
+		return fmt.Errorf("%w: -batch-size must be positive", errUsage)
+	}
+	fileFormat, err := parseFileFormat(*format, *file)
+	if err != nil {
+		return err
+	}
+	if *rejectedFile == "" {
+		*rejectedFile = *file + ".rejected.jsonl"
+	}
+
+	in, err := os.Open(*file)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = in.Close()
+	}()
+
+	// A file left by a previous import would be taken for the rows rejected by this one.
+	if err = os.Remove(*rejectedFile); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	rejected := &rejectedRows{path: *rejectedFile}
+	defer func() {
+		_ = rejected.close()
+	}()
+
+	storage := c.(*dbClient).storage
+	summary := importSummary{File: *file}
+	now := time.Now()
+	reader := newRowReader(in, fileFormat)
+
+	batch := make([]M, 0, *batchSize)
+	batchRows := make([]rejectedRow, 0, *batchSize)
+	flush := func() error {
+		skipped, err := upsert(storage, batch)
+		if err != nil {
+			return fmt.Errorf("importing rows from line %d, after %d rows imported: %w",
+				batchRows[0].Line, summary.Imported, err)
+		}
+
+		for _, i := range skipped {
+			row := batchRows[i]
+			row.Error = skippedError
+			if err = rejected.add(row); err != nil {
+				return err
+			}
+		}
+		summary.Imported += len(batch) - len(skipped)
+		summary.Rejected += len(skipped)
+
+		batch = batch[:0]
+		batchRows = batchRows[:0]
+
+		return nil
+	}
+
+	for {
+		rec := newRecord()
+		line, row, err := reader.next(rec)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		var rowErr rowError
+		if errors.As(err, &rowErr) {
+			summary.Rows++
+			summary.Rejected++
+			if err = rejected.add(rejectedRow{Line: line, Error: rowErr.Error(), Row: row}); err != nil {
+				return err
+			}
+
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		summary.Rows++
+
+		m, err := rec.toModel(now)
+		if err != nil {
+			summary.Rejected++
+			if err = rejected.add(rejectedRow{Line: line, Error: err.Error(), Row: row}); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		batch = append(batch, m)
+		batchRows = append(batchRows, rejectedRow{Line: line, Row: row})
+		if len(batch) == *batchSize {
+			if err = flush(); err != nil {
+				return err
+			}
+		}
+	}
+	if len(batch) > 0 {
+		if err = flush(); err != nil {
+			return err
+		}
+	}
+
+	if err = rejected.close(); err != nil {
+		return err
+	}
+	if summary.Rejected > 0 {
+		summary.RejectedFile = *rejectedFile
+	}
+
+	return p.print(summary,
+		[]string{"FILE", "ROWS", "IMPORTED", "REJECTED", "REJECTED FILE"},
+		[][]string{{
+			summary.File,
+			strconv.Itoa(summary.Rows),
+			strconv.Itoa(summary.Imported),
+			strconv.Itoa(summary.Rejected),
+			summary.RejectedFile,
+		}},
+	)
+}
+
+// rejectedRows is the file of rejected rows, created when the first row is rejected.
+type rejectedRows struct {
+	path    string
+	file    *os.File
+	encoder *json.Encoder
+}
+
+func (r *rejectedRows) add(row rejectedRow) error {
+	if r.file == nil {
+		file, err := os.Create(r.path)
+		if err != nil {
+			return err
+		}
+		r.file = file
+		r.encoder = json.NewEncoder(file)
+	}
+
+	return r.encoder.Encode(row)
+}
+
+func (r *rejectedRows) close() error {
+	if r.file == nil {
+		return nil
+	}
+
+	file := r.file
+	r.file = nil
+
+	return file.Close()
+}
+
+// parseFileFormat returns the format of the flag or, if it is not set, the format of the file extension.
+func parseFileFormat(format, file string) (string, error) {
+	switch format {
+	case fileFormatJSONL, fileFormatCSV:
+		return format, nil
+	case "":
+		if filepath.Ext(file) == ".csv" {
+			return fileFormatCSV, nil
+		}
+
+		return fileFormatJSONL, nil
+	default:
+		return "", fmt.Errorf("%w: unknown file format %q", errUsage, format)
+	}
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"context"
+	"love-signal-users/internal/enum"
+	"time"
+)
+
+// client runs the commands against a running instance or directly against the database.
+type client interface {
+	User(ctx context.Context, id int64) (userView, error)
+	UserByExternalID(ctx context.Context, externalID int64) (userView, error)
+	Follows(ctx context.Context, userID int64) ([]followView, error)
+	Follow(ctx context.Context, userID, userIDToFollow int64) error
+	Unfollow(ctx context.Context, followLinkID int64) error
+	DeactivateUser(ctx context.Context, id int64) error
+	RestoreUser(ctx context.Context, id int64) error
+	Stats(ctx context.Context) (statsView, error)
+	// ExportUserData returns the JSON document of everything stored about the user.
+	ExportUserData(ctx context.Context, externalID int64) ([]byte, error)
+	Close() error
+}
+
+// userView is the output of a user. The external ID is not returned by the gRPC API,
+// so it is known in the gRPC mode only when the user is looked up by it.
+type userView struct {
+	ID            int64      `json:"id"`
+	ExternalID    int64      `json:"externalId,omitempty"`
+	FullName      string     `json:"fullName"`
+	DateOfBirth   *time.Time `json:"dateOfBirth,omitempty"`
+	Gender        string     `json:"gender,omitempty"`
+	AvatarFileKey *string    `json:"avatarFileKey,omitempty"`
+}
+
+// followView is the output of a followed user.
+type followView struct {
+	FollowLinkID  int64   `json:"followLinkId"`
+	UserID        int64   `json:"userId"`
+	FullName      string  `json:"fullName"`
+	AvatarFileKey *string `json:"avatarFileKey,omitempty"`
+	NumberOfLikes uint32  `json:"numberOfLikes"`
+}
+
+// statsView is the output of the database stats.
+type statsView struct {
+	Users        int64 `json:"users"`
+	DeletedUsers int64 `json:"deletedUsers"`
+	Follows      int64 `json:"follows"`
+	Likes        int64 `json:"likes"`
+	SizeBytes    int64 `json:"sizeBytes"`
+}
+
+// genderName returns the name of the gender for the output.
+func genderName(gender *enum.Gender) string {
+	if gender == nil {
+		return ""
+	}
+
+	switch *gender {
+	case enum.MALE:
+		return "male"
+	case enum.FEMALE:
+		return "female"
+	default:
+		return "unspecified"
+	}
+}
+
+// genderByName returns the gender of the name returned by genderName.
+func genderByName(name string) (*enum.Gender, bool) {
+	var gender enum.Gender
+	switch name {
+	case "male":
+		gender = enum.MALE
+	case "female":
+		gender = enum.FEMALE
+	default:
+		return nil, false
+	}
+
+	return &gender, true
+}
@@ -0,0 +1,262 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// errUsage is returned when the command or its flags are invalid; the usage is printed.
+var errUsage = errors.New("invalid usage")
+
+// command is a command of the tool, called by one or two words, e.g. "stats" or "user get".
+type command struct {
+	name    string
+	summary string
+	run     func(ctx context.Context, c client, p printer, args []string) error
+	// dbOnly commands work only in the db mode, as they have no API.
+	dbOnly bool
+	// local commands read only local files; they run without a client in any mode.
+	local bool
+}
+
+var commands = []command{
+	{name: "user get", summary: "look up a user: -id or -external-id", run: userGet},
+	{name: "user deactivate", summary: "deactivate a user: -id", run: userDeactivate},
+	{name: "user restore", summary: "restore a deactivated user: -id", run: userRestore},
+	{name: "user export", summary: "export everything stored about a user as JSON: -external-id, -file", run: userExport},
+	{name: "follows list", summary: "list users followed by a user: -user", run: followsList},
+	{name: "follows create", summary: "make a user follow another one: -user, -target", run: followsCreate},
+	{name: "follows remove", summary: "remove a follow link: -link", run: followsRemove},
+	{name: "stats", summary: "print database stats", run: statsShow},
+	{name: "export users", summary: "export users to JSONL or CSV: -file, -format", run: exportUsers, dbOnly: true},
+	{name: "export follows", summary: "export follows to JSONL or CSV: -file, -format", run: exportFollows, dbOnly: true},
+	{name: "import users", summary: "upsert users by external ID: -file, -format, -batch-size, -rejected", run: importUsers, dbOnly: true},
+	{name: "import follows", summary: "upsert follows of imported users: -file, -format, -batch-size, -rejected", run: importFollows, dbOnly: true},
+	{name: "audit verify", summary: "check the hash chain of an audit log file: -path", run: auditVerify, local: true},
+}
+
+// findCommand returns the command called by the first arguments and the rest of the arguments.
+func findCommand(args []string) (command, []string, bool) {
+	for _, cmd := range commands {
+		if len(args) >= 2 && cmd.name == args[0]+" "+args[1] {
+			return cmd, args[2:], true
+		}
+		if len(args) >= 1 && cmd.name == args[0] {
+			return cmd, args[1:], true
+		}
+	}
+
+	return command{}, nil, false
+}
+
+// parseFlags parses the flags of the command. Errors and help are written to stderr.
+func parseFlags(fs *flag.FlagSet, args []string) error {
+	if err := fs.Parse(args); err != nil {
+		return errUsage
+	}
+	if fs.NArg() > 0 {
+		return fmt.Errorf("%w: unexpected arguments %v", errUsage, fs.Args())
+	}
+
+	return nil
+}
+
+func userGet(ctx context.Context, c client, p printer, args []string) error {
+	fs := flag.NewFlagSet("user get", flag.ContinueOnError)
+	id := fs.Int64("id", 0, "internal user ID")
+	externalID := fs.Int64("external-id", 0, "external user ID")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	var u userView
+	var err error
+	switch {
+	case *id != 0 && *externalID == 0:
+		u, err = c.User(ctx, *id)
+	case *externalID != 0 && *id == 0:
+		u, err = c.UserByExternalID(ctx, *externalID)
+	default:
+		return fmt.Errorf("%w: exactly one of -id and -external-id is required", errUsage)
+	}
+	if err != nil {
+		return err
+	}
+
+	dateOfBirth := ""
+	if u.DateOfBirth != nil {
+		dateOfBirth = u.DateOfBirth.Format(time.DateOnly)
+	}
+
+	header := []string{"ID", "FULL NAME", "DATE OF BIRTH", "GENDER", "AVATAR"}
+	row := []string{strconv.FormatInt(u.ID, 10), u.FullName, dateOfBirth, u.Gender, optionalString(u.AvatarFileKey)}
+
+	// The external ID is unknown in the gRPC mode when the user is looked up by the internal ID.
+	if u.ExternalID != 0 {
+		header = slices.Insert(header, 1, "EXTERNAL ID")
+		row = slices.Insert(row, 1, strconv.FormatInt(u.ExternalID, 10))
+	}
+
+	return p.print(u, header, [][]string{row})
+}
+
+func userDeactivate(ctx context.Context, c client, p printer, args []string) error {
+	id, err := parseID("user deactivate", "id", "user ID", args)
+	if err != nil {
+		return err
+	}
+
+	if err = c.DeactivateUser(ctx, id); err != nil {
+		return err
+	}
+
+	return p.printStatus()
+}
+
+func userRestore(ctx context.Context, c client, p printer, args []string) error {
+	id, err := parseID("user restore", "id", "user ID", args)
+	if err != nil {
+		return err
+	}
+
+	if err = c.RestoreUser(ctx, id); err != nil {
+		return err
+	}
+
+	return p.printStatus()
+}
+
+func userExport(ctx context.Context, c client, p printer, args []string) error {
+	fs := flag.NewFlagSet("user export", flag.ContinueOnError)
+	externalID := fs.Int64("external-id", 0, "external user ID")
+	file := fs.String("file", "", "file to write; by default, the document is written to stdout")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if *externalID == 0 {
+		return fmt.Errorf("%w: -external-id is required", errUsage)
+	}
+
+	doc, err := c.ExportUserData(ctx, *externalID)
+	if err != nil {
+		return err
+	}
+
+	if *file == "" {
+		_, err = p.w.Write(append(doc, '\n'))
+
+		return err
+	}
+	if err = os.WriteFile(*file, doc, 0o600); err != nil {
+		return err
+	}
+
+	return p.printStatus()
+}
+
+func followsList(ctx context.Context, c client, p printer, args []string) error {
+	userID, err := parseID("follows list", "user", "ID of the following user", args)
+	if err != nil {
+		return err
+	}
+
+	follows, err := c.Follows(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	rows := make([][]string, 0, len(follows))
+	for _, f := range follows {
+		rows = append(rows, []string{
+			strconv.FormatInt(f.FollowLinkID, 10),
+			strconv.FormatInt(f.UserID, 10),
+			f.FullName,
+			optionalString(f.AvatarFileKey),
+			strconv.FormatUint(uint64(f.NumberOfLikes), 10),
+		})
+	}
+
+	return p.print(follows, []string{"LINK ID", "USER ID", "FULL NAME", "AVATAR", "LIKES"}, rows)
+}
+
+func followsCreate(ctx context.Context, c client, p printer, args []string) error {
+	fs := flag.NewFlagSet("follows create", flag.ContinueOnError)
+	userID := fs.Int64("user", 0, "ID of the following user")
+	targetID := fs.Int64("target", 0, "ID of the user to follow")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if *userID == 0 || *targetID == 0 {
+		return fmt.Errorf("%w: -user and -target are required", errUsage)
+	}
+
+	if err := c.Follow(ctx, *userID, *targetID); err != nil {
+		return err
+	}
+
+	return p.printStatus()
+}
+
+func followsRemove(ctx context.Context, c client, p printer, args []string) error {
+	linkID, err := parseID("follows remove", "link", "follow link ID", args)
+	if err != nil {
+		return err
+	}
+
+	if err = c.Unfollow(ctx, linkID); err != nil {
+		return err
+	}
+
+	return p.printStatus()
+}
+
+func statsShow(ctx context.Context, c client, p printer, args []string) error {
+	fs := flag.NewFlagSet("stats", flag.ContinueOnError)
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	s, err := c.Stats(ctx)
+	if err != nil {
+		return err
+	}
+
+	return p.print(s,
+		[]string{"USERS", "DELETED USERS", "FOLLOWS", "LIKES", "SIZE BYTES"},
+		[][]string{{
+			strconv.FormatInt(s.Users, 10),
+			strconv.FormatInt(s.DeletedUsers, 10),
+			strconv.FormatInt(s.Follows, 10),
+			strconv.FormatInt(s.Likes, 10),
+			strconv.FormatInt(s.SizeBytes, 10),
+		}},
+	)
+}
+
+// parseID parses the only flag of the command, a required ID.
+func parseID(command, name, usage string, args []string) (int64, error) {
+	fs := flag.NewFlagSet(command, flag.ContinueOnError)
+	id := fs.Int64(name, 0, usage)
+	if err := parseFlags(fs, args); err != nil {
+		return 0, err
+	}
+	if *id == 0 {
+		return 0, fmt.Errorf("%w: -%s is required", errUsage, name)
+	}
+
+	return *id, nil
+}
+
+func optionalString(value *string) string {
+	if value == nil {
+		return ""
+	}
+
+	return *value
+}
@@ -0,0 +1,164 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"love-signal-users/internal/controller/document"
+	"love-signal-users/internal/entity"
+	auditrecorder "love-signal-users/internal/infrastructure/audit"
+	"love-signal-users/internal/infrastructure/repository"
+	"love-signal-users/internal/infrastructure/storage/sqlite"
+	"love-signal-users/internal/usecase/deactivate"
+	"love-signal-users/internal/usecase/externaluser"
+	"love-signal-users/internal/usecase/follow"
+	"love-signal-users/internal/usecase/followed"
+	"love-signal-users/internal/usecase/restore"
+	"love-signal-users/internal/usecase/stats"
+	"love-signal-users/internal/usecase/unfollow"
+	"love-signal-users/internal/usecase/user"
+	"love-signal-users/internal/usecase/userarchive"
+)
+
+// dbClient runs the commands directly against the database with the use-cases of the service.
+// Changes made in this mode are not published as follow events to subscribers of a running instance
+// and are not written to its audit log. The audit log is only read, for the history in user data exports.
+type dbClient struct {
+	storage *sqlite.Storage
+
+	userData             *user.UseCase
+	userDataByExternalID *externaluser.UseCase
+	followed             *followed.UseCase
+	follow               *follow.UseCase
+	unfollow             *unfollow.UseCase
+	deactivate           *deactivate.UseCase
+	restore              *restore.UseCase
+	stats                *stats.UseCase
+	userArchive          *userarchive.UseCase
+}
+
+// nopPublisher drops follow events, as there are no subscribers in this process.
+type nopPublisher struct{}
+
+func (nopPublisher) Publish(int64, entity.FollowEvent) {}
+
+func newDBClient(log *slog.Logger, storagePath, auditPath string) (*dbClient, error) {
+	const op = "usersctl.newDBClient"
+
+	storage, err := sqlite.New(storagePath)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	usersRepository := repository.NewUsersRepository(log, storage)
+	auditRecorder := auditrecorder.NewRecorder(log, nil)
+
+	return &dbClient{
+		storage: storage,
+
+		userData:             user.New(log, usersRepository),
+		userDataByExternalID: externaluser.New(log, usersRepository),
+		followed:             followed.New(log, usersRepository),
+		follow:               follow.New(log, usersRepository, nopPublisher{}, auditRecorder),
+		unfollow:             unfollow.New(log, usersRepository, nopPublisher{}, auditRecorder),
+		deactivate:           deactivate.New(log, usersRepository, auditRecorder),
+		restore:              restore.New(log, usersRepository, auditRecorder),
+		stats:                stats.New(log, usersRepository),
+		userArchive: userarchive.New(
+			log, usersRepository, auditrecorder.NewHistory(auditPath), auditRecorder,
+		),
+	}, nil
+}
+
+func (c *dbClient) User(ctx context.Context, id int64) (userView, error) {
+	u, err := c.userData.Execute(ctx, id)
+	if err != nil {
+		return userView{}, err
+	}
+
+	return toUserView(u), nil
+}
+
+func (c *dbClient) UserByExternalID(ctx context.Context, externalID int64) (userView, error) {
+	u, err := c.userDataByExternalID.Execute(ctx, externalID)
+	if err != nil {
+		return userView{}, err
+	}
+
+	return toUserView(u), nil
+}
+
+func (c *dbClient) Follows(ctx context.Context, userID int64) ([]followView, error) {
+	follows, err := c.followed.Execute(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	views := make([]followView, 0, len(follows))
+	for _, f := range follows {
+		views = append(views, followView{
+			FollowLinkID:  f.ID,
+			UserID:        f.FollowedUser.ID,
+			FullName:      f.FollowedUser.FullName,
+			AvatarFileKey: f.FollowedUser.AvatarFileKey,
+			NumberOfLikes: f.NumberOfLikes,
+		})
+	}
+
+	return views, nil
+}
+
+func (c *dbClient) Follow(ctx context.Context, userID, userIDToFollow int64) error {
+	return c.follow.Execute(ctx, userID, userIDToFollow)
+}
+
+func (c *dbClient) Unfollow(ctx context.Context, followLinkID int64) error {
+	return c.unfollow.Execute(ctx, followLinkID)
+}
+
+func (c *dbClient) DeactivateUser(ctx context.Context, id int64) error {
+	return c.deactivate.Execute(ctx, id)
+}
+
+func (c *dbClient) RestoreUser(ctx context.Context, id int64) error {
+	return c.restore.Execute(ctx, id)
+}
+
+func (c *dbClient) Stats(ctx context.Context) (statsView, error) {
+	s, err := c.stats.Execute(ctx)
+	if err != nil {
+		return statsView{}, err
+	}
+
+	return statsView{
+		Users:        s.Users,
+		DeletedUsers: s.DeletedUsers,
+		Follows:      s.Follows,
+		Likes:        s.Likes,
+		SizeBytes:    s.SizeBytes,
+	}, nil
+}
+
+func (c *dbClient) ExportUserData(ctx context.Context, externalID int64) ([]byte, error) {
+	archive, err := c.userArchive.Execute(ctx, externalID)
+	if err != nil {
+		return nil, err
+	}
+
+	return document.MarshalUserArchive(archive)
+}
+
+func (c *dbClient) Close() error {
+	return c.storage.Close()
+}
+
+func toUserView(u entity.User) userView {
+	return userView{
+		ID:            u.ID,
+		ExternalID:    u.ExternalID,
+		FullName:      u.FullName,
+		DateOfBirth:   u.DateOfBirth,
+		Gender:        genderName(u.Gender),
+		AvatarFileKey: u.AvatarFileKey,
+	}
+}
@@ -0,0 +1,221 @@
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	lsuserspb "github.com/p1xray/love-signal-protos/gen/go/users"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	lsadminpb "love-signal-users/gen/go/admin"
+	"love-signal-users/internal/enum"
+	"os"
+)
+
+// errCallFailed is returned when the service answers a state-changing call without success.
+var errCallFailed = errors.New("call was not successful")
+
+// tlsFlags are the files of the client TLS. The client certificate identifies the operator for the admin service.
+type tlsFlags struct {
+	caFile   string
+	certFile string
+	keyFile  string
+}
+
+// grpcClient runs the commands against a running instance.
+type grpcClient struct {
+	conn  *grpc.ClientConn
+	users lsuserspb.UsersClient
+	admin lsadminpb.AdminClient
+}
+
+func newGRPCClient(address string, tlsFiles tlsFlags) (*grpcClient, error) {
+	const op = "usersctl.newGRPCClient"
+
+	creds := insecure.NewCredentials()
+	if tlsFiles.caFile != "" {
+		tlsConfig, err := newClientTLSConfig(tlsFiles)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		creds = credentials.NewTLS(tlsConfig)
+	}
+
+	conn, err := grpc.NewClient(address, grpc.WithTransportCredentials(creds))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &grpcClient{
+		conn:  conn,
+		users: lsuserspb.NewUsersClient(conn),
+		admin: lsadminpb.NewAdminClient(conn),
+	}, nil
+}
+
+func newClientTLSConfig(tlsFiles tlsFlags) (*tls.Config, error) {
+	caPEM, err := os.ReadFile(tlsFiles.caFile)
+	if err != nil {
+		return nil, err
+	}
+
+	roots := x509.NewCertPool()
+	if !roots.AppendCertsFromPEM(caPEM) {
+		return nil, fmt.Errorf("no certificates in %s", tlsFiles.caFile)
+	}
+
+	tlsConfig := &tls.Config{RootCAs: roots, MinVersion: tls.VersionTLS12}
+	if tlsFiles.certFile != "" {
+		cert, err := tls.LoadX509KeyPair(tlsFiles.certFile, tlsFiles.keyFile)
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return tlsConfig, nil
+}
+
+func (c *grpcClient) User(ctx context.Context, id int64) (userView, error) {
+	resp, err := c.users.GetUserData(ctx, &lsuserspb.GetUserDataRequest{UserId: id})
+	if err != nil {
+		return userView{}, err
+	}
+
+	return toUserViewFromPb(resp, 0), nil
+}
+
+func (c *grpcClient) UserByExternalID(ctx context.Context, externalID int64) (userView, error) {
+	resp, err := c.users.GetUserDataByExternalId(ctx, &lsuserspb.GetUserDataByExternalIdRequest{UserExternalId: externalID})
+	if err != nil {
+		return userView{}, err
+	}
+
+	return toUserViewFromPb(resp, externalID), nil
+}
+
+func (c *grpcClient) Follows(ctx context.Context, userID int64) ([]followView, error) {
+	resp, err := c.users.GetFollowedUsers(ctx, &lsuserspb.GetFollowedUsersRequest{UserId: userID})
+	if err != nil {
+		return nil, err
+	}
+
+	follows := make([]followView, 0, len(resp.GetUsers()))
+	for _, u := range resp.GetUsers() {
+		var avatarFileKey *string
+		if u.GetAvatarFileKey() != nil {
+			value := u.GetAvatarFileKey().GetValue()
+			avatarFileKey = &value
+		}
+
+		follows = append(follows, followView{
+			FollowLinkID:  u.GetFollowLinkId(),
+			UserID:        u.GetUserId(),
+			FullName:      u.GetFullName(),
+			AvatarFileKey: avatarFileKey,
+			NumberOfLikes: u.GetNumberOfLikes(),
+		})
+	}
+
+	return follows, nil
+}
+
+func (c *grpcClient) Follow(ctx context.Context, userID, userIDToFollow int64) error {
+	resp, err := c.users.FollowUser(ctx, &lsuserspb.FollowUserRequest{UserId: userID, UserIdToFollow: userIDToFollow})
+	if err != nil {
+		return err
+	}
+
+	return successError(resp.GetSuccess())
+}
+
+func (c *grpcClient) Unfollow(ctx context.Context, followLinkID int64) error {
+	resp, err := c.users.UnfollowUser(ctx, &lsuserspb.UnfollowUserRequest{FollowLinkId: followLinkID})
+	if err != nil {
+		return err
+	}
+
+	return successError(resp.GetSuccess())
+}
+
+func (c *grpcClient) DeactivateUser(ctx context.Context, id int64) error {
+	resp, err := c.admin.DeactivateUser(ctx, &lsadminpb.DeactivateUserRequest{UserId: id})
+	if err != nil {
+		return err
+	}
+
+	return successError(resp.GetSuccess())
+}
+
+func (c *grpcClient) RestoreUser(ctx context.Context, id int64) error {
+	resp, err := c.admin.RestoreUser(ctx, &lsadminpb.RestoreUserRequest{UserId: id})
+	if err != nil {
+		return err
+	}
+
+	return successError(resp.GetSuccess())
+}
+
+func (c *grpcClient) Stats(ctx context.Context) (statsView, error) {
+	resp, err := c.admin.GetStats(ctx, &lsadminpb.GetStatsRequest{})
+	if err != nil {
+		return statsView{}, err
+	}
+
+	return statsView{
+		Users:        resp.GetUsers(),
+		DeletedUsers: resp.GetDeletedUsers(),
+		Follows:      resp.GetFollows(),
+		Likes:        resp.GetLikes(),
+		SizeBytes:    resp.GetSizeBytes(),
+	}, nil
+}
+
+func (c *grpcClient) ExportUserData(ctx context.Context, externalID int64) ([]byte, error) {
+	resp, err := c.admin.ExportUserData(ctx, &lsadminpb.ExportUserDataRequest{UserExternalId: externalID})
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.GetDocument(), nil
+}
+
+func (c *grpcClient) Close() error {
+	return c.conn.Close()
+}
+
+func toUserViewFromPb(resp *lsuserspb.UserDataResponse, externalID int64) userView {
+	user := userView{
+		ID:         resp.GetId(),
+		ExternalID: externalID,
+		FullName:   resp.GetFullName(),
+	}
+
+	if resp.GetDateOfBirth() != nil {
+		dateOfBirth := resp.GetDateOfBirth().AsTime()
+		user.DateOfBirth = &dateOfBirth
+	}
+
+	if resp.GetGender() != lsuserspb.Gender_GENDER_UNSPECIFIED {
+		gender := enum.Gender(resp.GetGender())
+		user.Gender = genderName(&gender)
+	}
+
+	if resp.GetAvatarFileKey() != nil {
+		avatarFileKey := resp.GetAvatarFileKey().GetValue()
+		user.AvatarFileKey = &avatarFileKey
+	}
+
+	return user
+}
+
+func successError(success bool) error {
+	if !success {
+		return errCallFailed
+	}
+
+	return nil
+}
@@ -0,0 +1,147 @@
+// Command usersctl is the operator tool of the users service.
+// Each command runs either against a running instance over gRPC or directly against the database.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"time"
+)
+
+// Modes of running the commands.
+const (
+	modeGRPC = "grpc"
+	modeDB   = "db"
+)
+
+func main() {
+	if err := run(os.Args[1:]); err != nil {
+		fmt.Fprintf(os.Stderr, "usersctl: %v\n", err)
+		if errors.Is(err, errUsage) {
+			usage()
+		}
+
+		os.Exit(1)
+	}
+}
+
+// globalFlags are the flags of all the commands.
+type globalFlags struct {
+	mode        string
+	address     string
+	storagePath string
+	auditPath   string
+	format      string
+	timeout     time.Duration
+	verbose     bool
+	tlsFiles    tlsFlags
+}
+
+// newFlagSet returns the flag set of the global flags.
+func newFlagSet() (*flag.FlagSet, *globalFlags) {
+	fs := flag.NewFlagSet("usersctl", flag.ContinueOnError)
+	// Errors and usage are printed by main.
+	fs.SetOutput(io.Discard)
+	fs.Usage = func() {}
+
+	var flags globalFlags
+	fs.StringVar(&flags.mode, "mode", modeGRPC, "how to run the command: grpc against a running instance or db directly")
+	fs.StringVar(&flags.address, "address", "localhost:6005", "gRPC address of the instance in grpc mode")
+	fs.StringVar(&flags.storagePath, "storage-path", "./storage/users.db", "path to storage in db mode")
+	fs.StringVar(&flags.auditPath, "audit-path", "", "audit log file of the instance, read for the history of user data exports in db mode")
+	fs.StringVar(&flags.format, "output", formatTable, "output format: table or json")
+	fs.DurationVar(&flags.timeout, "timeout", 10*time.Second, "timeout of the command; 0 means none, e.g. for exports and imports of large tables")
+	fs.BoolVar(&flags.verbose, "verbose", false, "write logs of the db mode to stderr")
+	fs.StringVar(&flags.tlsFiles.caFile, "tls-ca", "", "CA certificate file of the instance; TLS is off without it")
+	fs.StringVar(&flags.tlsFiles.certFile, "tls-cert", "", "client certificate file, identifying the operator for admin commands")
+	fs.StringVar(&flags.tlsFiles.keyFile, "tls-key", "", "client key file")
+
+	return fs, &flags
+}
+
+func run(args []string) error {
+	fs, flags := newFlagSet()
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return errUsage
+		}
+
+		return fmt.Errorf("%w: %w", errUsage, err)
+	}
+
+	if flags.format != formatTable && flags.format != formatJSON {
+		return fmt.Errorf("%w: unknown output format %q", errUsage, flags.format)
+	}
+
+	cmd, cmdArgs, ok := findCommand(fs.Args())
+	if !ok {
+		return fmt.Errorf("%w: unknown command %v", errUsage, fs.Args())
+	}
+	if cmd.dbOnly && flags.mode != modeDB {
+		return fmt.Errorf("%w: %s works only with -mode %s", errUsage, cmd.name, modeDB)
+	}
+
+	ctx := context.Background()
+	if flags.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, flags.timeout)
+		defer cancel()
+	}
+
+	p := printer{w: os.Stdout, format: flags.format}
+	if cmd.local {
+		return cmd.run(ctx, nil, p, cmdArgs)
+	}
+
+	var c client
+	var err error
+	switch flags.mode {
+	case modeGRPC:
+		c, err = newGRPCClient(flags.address, flags.tlsFiles)
+	case modeDB:
+		log := slog.New(slog.DiscardHandler)
+		if flags.verbose {
+			log = slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelDebug}))
+		}
+		c, err = newDBClient(log, flags.storagePath, flags.auditPath)
+	default:
+		return fmt.Errorf("%w: unknown mode %q", errUsage, flags.mode)
+	}
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = c.Close()
+	}()
+
+	return cmd.run(ctx, c, p, cmdArgs)
+}
+
+func usage() {
+	out := os.Stderr
+
+	fmt.Fprintln(out, "Usage: usersctl [flags] <command> [command flags]")
+	fmt.Fprintln(out)
+	fmt.Fprintln(out, "Commands:")
+	for _, cmd := range commands {
+		fmt.Fprintf(out, "  %-16s %s\n", cmd.name, cmd.summary)
+	}
+	fmt.Fprintln(out)
+	fmt.Fprintln(out, "Changes made in db mode are not published to follow event subscribers and are not audited.")
+	fmt.Fprintln(out, "User exports in db mode include the audit history only with -audit-path.")
+	fmt.Fprintln(out, "Export and import work in db mode only. Users and follows are keyed by external ID;")
+	fmt.Fprintln(out, "import users before their follows.")
+	fmt.Fprintln(out, "User interests must be interest tags; in CSV files, they are separated by commas.")
+	fmt.Fprintln(out, "Audit verify reads the audit log file directly and needs neither the instance nor the database.")
+	fmt.Fprintln(out)
+	fmt.Fprintln(out, "Flags:")
+
+	fs, _ := newFlagSet()
+	fs.SetOutput(out)
+	fs.PrintDefaults()
+}
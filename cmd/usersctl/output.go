@@ -0,0 +1,49 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+)
+
+// Output formats.
+const (
+	formatTable = "table"
+	formatJSON  = "json"
+)
+
+// statusOK is the output of a successful state-changing command.
+const statusOK = "ok"
+
+// printer prints the results of the commands in the output format.
+type printer struct {
+	w      io.Writer
+	format string
+}
+
+// print prints the value as indented JSON, or as the table of the header and the rows.
+func (p printer) print(value any, header []string, rows [][]string) error {
+	if p.format == formatJSON {
+		encoder := json.NewEncoder(p.w)
+		encoder.SetIndent("", "  ")
+
+		return encoder.Encode(value)
+	}
+
+	tw := tabwriter.NewWriter(p.w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, strings.Join(header, "\t"))
+	for _, row := range rows {
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+
+	return tw.Flush()
+}
+
+// printStatus prints the result of a successful state-changing command.
+func (p printer) printStatus() error {
+	return p.print(struct {
+		Status string `json:"status"`
+	}{Status: statusOK}, []string{"STATUS"}, [][]string{{statusOK}})
+}
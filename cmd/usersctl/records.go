@@ -0,0 +1,378 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"github.com/guregu/null/v6"
+	"love-signal-users/internal/entity"
+	"love-signal-users/internal/enum"
+	"love-signal-users/internal/infrastructure/storage/models"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	// maxFullNameLength is the length of the full_name column.
+	maxFullNameLength = 255
+	// interestSeparator separates the interests of a user in CSV files.
+	interestSeparator = ","
+)
+
+// userRecord is a user in an exported or imported file. Users are identified by external ID,
+// as internal IDs differ between environments. Missing times of imported users are set to the import time.
+// Interests are interest tag names; in CSV files, they are separated by commas.
+type userRecord struct {
+	ExternalID    int64      `json:"externalId"`
+	FullName      string     `json:"fullName"`
+	DateOfBirth   *string    `json:"dateOfBirth"`
+	Gender        *string    `json:"gender"`
+	AvatarFileKey *string    `json:"avatarFileKey"`
+	Bio           *string    `json:"bio"`
+	City          *string    `json:"city"`
+	Interests     []string   `json:"interests"`
+	Deleted       bool       `json:"deleted"`
+	CreatedAt     *time.Time `json:"createdAt"`
+	UpdatedAt     *time.Time `json:"updatedAt"`
+}
+
+func newUserRecord(user models.UserWithInterests) *userRecord {
+	u := user.User
+
+	var dateOfBirth *string
+	if u.DateOfBirth.Valid {
+		value := u.DateOfBirth.Time.Format(time.DateOnly)
+		dateOfBirth = &value
+	}
+
+	var gender *string
+	if u.Gender.Valid {
+		value := genderName(enum.GenderFromNullInt16(u.Gender))
+		gender = &value
+	}
+
+	return &userRecord{
+		ExternalID:    u.ExternalID,
+		FullName:      u.FullName,
+		DateOfBirth:   dateOfBirth,
+		Gender:        gender,
+		AvatarFileKey: u.AvatarFileKey.Ptr(),
+		Bio:           u.Bio.Ptr(),
+		City:          u.City.Ptr(),
+		Interests:     user.Interests,
+		Deleted:       u.Deleted,
+		CreatedAt:     &u.CreatedAt,
+		UpdatedAt:     &u.UpdatedAt,
+	}
+}
+
+func (r *userRecord) columns() []string {
+	return []string{
+		"externalId", "fullName", "dateOfBirth", "gender", "avatarFileKey", "bio", "city", "interests", "deleted",
+		"createdAt", "updatedAt",
+	}
+}
+
+func (r *userRecord) values() []string {
+	return []string{
+		strconv.FormatInt(r.ExternalID, 10),
+		r.FullName,
+		optionalString(r.DateOfBirth),
+		optionalString(r.Gender),
+		optionalString(r.AvatarFileKey),
+		optionalString(r.Bio),
+		optionalString(r.City),
+		strings.Join(r.Interests, interestSeparator),
+		strconv.FormatBool(r.Deleted),
+		optionalTime(r.CreatedAt),
+		optionalTime(r.UpdatedAt),
+	}
+}
+
+func (r *userRecord) setValues(values map[string]string) error {
+	var err error
+	if r.ExternalID, err = parseInt(values, "externalId"); err != nil {
+		return err
+	}
+	r.FullName = values["fullName"]
+	r.DateOfBirth = nonEmpty(values["dateOfBirth"])
+	r.Gender = nonEmpty(values["gender"])
+	r.AvatarFileKey = nonEmpty(values["avatarFileKey"])
+	r.Bio = nonEmpty(values["bio"])
+	r.City = nonEmpty(values["city"])
+	if value := values["interests"]; value != "" {
+		r.Interests = strings.Split(value, interestSeparator)
+	}
+	if value := values["deleted"]; value != "" {
+		if r.Deleted, err = strconv.ParseBool(value); err != nil {
+			return fmt.Errorf("invalid deleted %q", value)
+		}
+	}
+	if r.CreatedAt, err = parseTime(values, "createdAt"); err != nil {
+		return err
+	}
+	if r.UpdatedAt, err = parseTime(values, "updatedAt"); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// toModel validates the user and returns it for storage. Bio and city are trimmed, as on profile updates,
+// and interests are deduplicated, in lower case and in order of name.
+func (r *userRecord) toModel(now time.Time) (models.UserWithInterests, error) {
+	var errs []error
+
+	if r.ExternalID <= 0 {
+		errs = append(errs, errors.New("externalId must be positive"))
+	}
+
+	fullName := strings.TrimSpace(r.FullName)
+	if fullName == "" {
+		errs = append(errs, errors.New("fullName is required"))
+	}
+	if utf8.RuneCountInString(fullName) > maxFullNameLength {
+		errs = append(errs, fmt.Errorf("fullName is longer than %d characters", maxFullNameLength))
+	}
+
+	var dateOfBirth null.Time
+	if r.DateOfBirth != nil {
+		value, err := time.Parse(time.DateOnly, *r.DateOfBirth)
+		switch {
+		case err != nil:
+			errs = append(errs, fmt.Errorf("invalid dateOfBirth %q: expected YYYY-MM-DD", *r.DateOfBirth))
+		case value.After(now):
+			errs = append(errs, errors.New("dateOfBirth is in the future"))
+		default:
+			dateOfBirth = null.TimeFrom(value)
+		}
+	}
+
+	var gender null.Int16
+	if r.Gender != nil {
+		value, ok := genderByName(*r.Gender)
+		if ok {
+			gender = value.ToNullInt16()
+		} else {
+			errs = append(errs, fmt.Errorf("invalid gender %q: expected male or female", *r.Gender))
+		}
+	}
+
+	var avatarFileKey null.String
+	if r.AvatarFileKey != nil {
+		avatarFileKey = null.StringFrom(*r.AvatarFileKey)
+	}
+
+	bio := null.StringFromPtr(trimmed(r.Bio))
+	if utf8.RuneCountInString(bio.String) > entity.MaxBioLength {
+		errs = append(errs, fmt.Errorf("bio is longer than %d characters", entity.MaxBioLength))
+	}
+
+	city := null.StringFromPtr(trimmed(r.City))
+	if utf8.RuneCountInString(city.String) > entity.MaxCityLength {
+		errs = append(errs, fmt.Errorf("city is longer than %d characters", entity.MaxCityLength))
+	}
+
+	interests := make([]string, 0, len(r.Interests))
+	for _, interest := range r.Interests {
+		interest = strings.ToLower(strings.TrimSpace(interest))
+		if interest == "" {
+			errs = append(errs, errors.New("interests must not be empty"))
+
+			break
+		}
+		if !slices.Contains(interests, interest) {
+			interests = append(interests, interest)
+		}
+	}
+	slices.Sort(interests)
+	if len(interests) > entity.MaxInterests {
+		errs = append(errs, fmt.Errorf("more than %d interests", entity.MaxInterests))
+	}
+
+	createdAt, updatedAt, err := recordTimes(r.CreatedAt, r.UpdatedAt, now)
+	if err != nil {
+		errs = append(errs, err)
+	}
+
+	if len(errs) > 0 {
+		return models.UserWithInterests{}, errors.Join(errs...)
+	}
+
+	return models.UserWithInterests{
+		User: models.User{
+			ExternalID:    r.ExternalID,
+			FullName:      fullName,
+			DateOfBirth:   dateOfBirth,
+			Gender:        gender,
+			AvatarFileKey: avatarFileKey,
+			Bio:           bio,
+			City:          city,
+			Deleted:       r.Deleted,
+			CreatedAt:     createdAt,
+			UpdatedAt:     updatedAt,
+		},
+		Interests: interests,
+	}, nil
+}
+
+// followRecord is a follow link in an exported or imported file, between users identified by external ID.
+type followRecord struct {
+	FollowingExternalID int64      `json:"followingExternalId"`
+	FollowedExternalID  int64      `json:"followedExternalId"`
+	NumberOfLikes       int64      `json:"numberOfLikes"`
+	CreatedAt           *time.Time `json:"createdAt"`
+	UpdatedAt           *time.Time `json:"updatedAt"`
+}
+
+func newFollowRecord(f models.ExternalFollow) *followRecord {
+	return &followRecord{
+		FollowingExternalID: f.FollowingExternalID,
+		FollowedExternalID:  f.FollowedExternalID,
+		NumberOfLikes:       int64(f.NumberOfLikes),
+		CreatedAt:           &f.CreatedAt,
+		UpdatedAt:           &f.UpdatedAt,
+	}
+}
+
+func (r *followRecord) columns() []string {
+	return []string{"followingExternalId", "followedExternalId", "numberOfLikes", "createdAt", "updatedAt"}
+}
+
+func (r *followRecord) values() []string {
+	return []string{
+		strconv.FormatInt(r.FollowingExternalID, 10),
+		strconv.FormatInt(r.FollowedExternalID, 10),
+		strconv.FormatInt(r.NumberOfLikes, 10),
+		optionalTime(r.CreatedAt),
+		optionalTime(r.UpdatedAt),
+	}
+}
+
+func (r *followRecord) setValues(values map[string]string) error {
+	var err error
+	if r.FollowingExternalID, err = parseInt(values, "followingExternalId"); err != nil {
+		return err
+	}
+	if r.FollowedExternalID, err = parseInt(values, "followedExternalId"); err != nil {
+		return err
+	}
+	if r.NumberOfLikes, err = parseInt(values, "numberOfLikes"); err != nil {
+		return err
+	}
+	if r.CreatedAt, err = parseTime(values, "createdAt"); err != nil {
+		return err
+	}
+	if r.UpdatedAt, err = parseTime(values, "updatedAt"); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// toModel validates the follow link and returns it for storage.
+func (r *followRecord) toModel(now time.Time) (models.ExternalFollow, error) {
+	var errs []error
+
+	if r.FollowingExternalID <= 0 {
+		errs = append(errs, errors.New("followingExternalId must be positive"))
+	}
+	if r.FollowedExternalID <= 0 {
+		errs = append(errs, errors.New("followedExternalId must be positive"))
+	}
+	if r.FollowingExternalID == r.FollowedExternalID {
+		errs = append(errs, errors.New("user can't follow themselves"))
+	}
+	if r.NumberOfLikes < 0 || r.NumberOfLikes > math.MaxUint32 {
+		errs = append(errs, fmt.Errorf("numberOfLikes must be between 0 and %d", uint32(math.MaxUint32)))
+	}
+
+	createdAt, updatedAt, err := recordTimes(r.CreatedAt, r.UpdatedAt, now)
+	if err != nil {
+		errs = append(errs, err)
+	}
+
+	if len(errs) > 0 {
+		return models.ExternalFollow{}, errors.Join(errs...)
+	}
+
+	return models.ExternalFollow{
+		FollowingExternalID: r.FollowingExternalID,
+		FollowedExternalID:  r.FollowedExternalID,
+		NumberOfLikes:       uint32(r.NumberOfLikes),
+		CreatedAt:           createdAt,
+		UpdatedAt:           updatedAt,
+	}, nil
+}
+
+// recordTimes returns the creation and update times of a record, set to now if they are missing.
+func recordTimes(createdAt, updatedAt *time.Time, now time.Time) (time.Time, time.Time, error) {
+	created, updated := now, now
+	if createdAt != nil {
+		created = *createdAt
+	}
+	if updatedAt != nil {
+		updated = *updatedAt
+	}
+	if updated.Before(created) {
+		return time.Time{}, time.Time{}, errors.New("updatedAt is before createdAt")
+	}
+
+	return created, updated, nil
+}
+
+func parseInt(values map[string]string, column string) (int64, error) {
+	value := values[column]
+	if value == "" {
+		return 0, nil
+	}
+
+	number, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s %q: expected an integer", column, value)
+	}
+
+	return number, nil
+}
+
+func parseTime(values map[string]string, column string) (*time.Time, error) {
+	value := values[column]
+	if value == "" {
+		return nil, nil
+	}
+
+	t, err := time.Parse(time.RFC3339Nano, value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s %q: expected an RFC 3339 time", column, value)
+	}
+
+	return &t, nil
+}
+
+func optionalTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+
+	return t.Format(time.RFC3339Nano)
+}
+
+// trimmed returns the value without leading and trailing spaces, or nil if it is empty.
+func trimmed(value *string) *string {
+	if value == nil {
+		return nil
+	}
+
+	return nonEmpty(strings.TrimSpace(*value))
+}
+
+func nonEmpty(value string) *string {
+	if value == "" {
+		return nil
+	}
+
+	return &value
+}
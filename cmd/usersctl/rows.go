@@ -0,0 +1,202 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+)
+
+// Formats of the exported and imported files.
+const (
+	fileFormatJSONL = "jsonl"
+	fileFormatCSV   = "csv"
+)
+
+// maxJSONLLineSize is the maximum size of a line of an imported JSONL file.
+const maxJSONLLineSize = 1 << 20
+
+// record is a row of an exported or imported file. In CSV, the columns are the JSON names of the fields.
+type record interface {
+	columns() []string
+	values() []string
+	setValues(values map[string]string) error
+}
+
+// rowWriter writes records to a file in the format, one per line.
+type rowWriter struct {
+	w      *bufio.Writer
+	format string
+	csv    *csv.Writer
+}
+
+// newRowWriter returns the writer of the records with the columns. In CSV, the header is written first.
+func newRowWriter(w io.Writer, format string, columns []string) *rowWriter {
+	bw := bufio.NewWriter(w)
+	writer := &rowWriter{w: bw, format: format, csv: csv.NewWriter(bw)}
+	if format == fileFormatCSV {
+		// The error is kept by the CSV writer and returned by flush.
+		_ = writer.csv.Write(columns)
+	}
+
+	return writer
+}
+
+func (w *rowWriter) write(r record) error {
+	if w.format == fileFormatJSONL {
+		row, err := json.Marshal(r)
+		if err != nil {
+			return err
+		}
+		row = append(row, '\n')
+		_, err = w.w.Write(row)
+
+		return err
+	}
+
+	return w.csv.Write(r.values())
+}
+
+// flush writes the buffered rows.
+func (w *rowWriter) flush() error {
+	w.csv.Flush()
+	if err := w.csv.Error(); err != nil {
+		return err
+	}
+
+	return w.w.Flush()
+}
+
+// rowError is an error of an imported row. The row is rejected, and the import goes on.
+type rowError struct {
+	err error
+}
+
+func (e rowError) Error() string {
+	return e.err.Error()
+}
+
+// rowReader reads records from a file in the format.
+type rowReader struct {
+	format  string
+	scanner *bufio.Scanner
+	csv     *csv.Reader
+	header  []string
+	line    int
+}
+
+func newRowReader(r io.Reader, format string) *rowReader {
+	reader := &rowReader{format: format}
+	if format == fileFormatJSONL {
+		reader.scanner = bufio.NewScanner(r)
+		reader.scanner.Buffer(make([]byte, 0, 64*1024), maxJSONLLineSize)
+	} else {
+		reader.csv = csv.NewReader(r)
+		reader.csv.FieldsPerRecord = -1
+	}
+
+	return reader
+}
+
+// next reads the next row into the record, which must be new, and returns its line number and its text.
+// It returns io.EOF at the end, a rowError if the row is invalid and other errors if the file can't be read.
+func (r *rowReader) next(rec record) (int, string, error) {
+	if r.format == fileFormatJSONL {
+		return r.nextJSONL(rec)
+	}
+
+	return r.nextCSV(rec)
+}
+
+func (r *rowReader) nextJSONL(rec record) (int, string, error) {
+	for r.scanner.Scan() {
+		r.line++
+		row := r.scanner.Bytes()
+		if len(bytes.TrimSpace(row)) == 0 {
+			continue
+		}
+
+		decoder := json.NewDecoder(bytes.NewReader(row))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(rec); err != nil {
+			return r.line, string(row), rowError{err: fmt.Errorf("invalid JSON: %w", err)}
+		}
+		if decoder.More() {
+			return r.line, string(row), rowError{err: errors.New("invalid JSON: more than one value in the line")}
+		}
+
+		return r.line, string(row), nil
+	}
+	if err := r.scanner.Err(); err != nil {
+		return r.line, "", err
+	}
+
+	return r.line, "", io.EOF
+}
+
+func (r *rowReader) nextCSV(rec record) (int, string, error) {
+	if r.header == nil {
+		header, err := r.csv.Read()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return 0, "", io.EOF
+			}
+
+			return 0, "", fmt.Errorf("invalid CSV header: %w", err)
+		}
+		for _, column := range header {
+			if !slices.Contains(rec.columns(), column) {
+				return 1, "", fmt.Errorf("unknown CSV column %q: expected %s", column, strings.Join(rec.columns(), ", "))
+			}
+		}
+		r.header = header
+	}
+
+	values, err := r.csv.Read()
+	if err != nil {
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			return parseErr.StartLine, "", rowError{err: err}
+		}
+
+		return 0, "", err
+	}
+
+	line, _ := r.csv.FieldPos(0)
+	row := csvLine(values)
+	if len(values) != len(r.header) {
+		return line, row, rowError{err: fmt.Errorf("expected %d values, got %d", len(r.header), len(values))}
+	}
+
+	named := make(map[string]string, len(values))
+	for i, value := range values {
+		named[r.header[i]] = value
+	}
+	if err = rec.setValues(named); err != nil {
+		return line, row, rowError{err: err}
+	}
+
+	return line, row, nil
+}
+
+// csvLine returns the values as a CSV line without the line break.
+func csvLine(values []string) string {
+	var b strings.Builder
+	w := csv.NewWriter(&b)
+	_ = w.Write(values)
+	w.Flush()
+
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// rejectedRow is a row of the file of rejected rows.
+type rejectedRow struct {
+	Line  int    `json:"line"`
+	Error string `json:"error"`
+	Row   string `json:"row"`
+}
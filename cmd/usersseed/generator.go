@@ -0,0 +1,178 @@
+package main
+
+import (
+	"fmt"
+	"github.com/guregu/null/v6"
+	"love-signal-users/internal/enum"
+	"love-signal-users/internal/infrastructure/storage/models"
+	"math"
+	"math/rand/v2"
+	"time"
+)
+
+// outDegreeSigma is the sigma of the log-normal distribution of the numbers of users followed by a user.
+// With 1, a few users follow about ten times the mean and many follow a handful.
+const outDegreeSigma = 1.0
+
+// Distributions of the user data.
+const (
+	minAge            = 18
+	meanAgeAboveMin   = 10
+	maxAge            = 70
+	noBirthDateShare  = 0.1
+	noGenderShare     = 0.04
+	registrationYears = 3
+)
+
+// options are the parameters of the generated data.
+type options struct {
+	users           int
+	followsPerUser  float64
+	maxFollows      int
+	inDegreeExp     float64
+	mutualShare     float64
+	likedShare      float64
+	meanLikes       float64
+	deletedShare    float64
+	avatarShare     float64
+	externalIDStart int64
+	referenceTime   time.Time
+}
+
+// generator generates users and follow links. The data depend only on the seed and the options:
+// users and follows are drawn from separate streams, so the batching of the writes does not change them.
+type generator struct {
+	opts        options
+	usersRand   *rand.Rand
+	followsRand *rand.Rand
+
+	// popularity maps the popularity rank to the index of the user.
+	popularity []int
+	// rankPower turns a uniform number into a popularity rank so that the numbers of followers
+	// follow a power law with the exponent of the options.
+	rankPower float64
+	// createdAt are the registration times of the users, in Unix seconds.
+	createdAt []int64
+}
+
+func newGenerator(seed uint64, opts options) *generator {
+	usersRand := rand.New(rand.NewPCG(seed, 1))
+	followsRand := rand.New(rand.NewPCG(seed, 2))
+
+	// Followers of the user of rank r are proportional to r^(-b) with b = 1/(exp-1),
+	// which is what picking rank n*u^(1/(1-b)) for uniform u gives.
+	b := 1 / (opts.inDegreeExp - 1)
+
+	return &generator{
+		opts:        opts,
+		usersRand:   usersRand,
+		followsRand: followsRand,
+		popularity:  followsRand.Perm(opts.users),
+		rankPower:   1 / (1 - b),
+		createdAt:   make([]int64, opts.users),
+	}
+}
+
+// user generates the user with the index i. Users must be generated in order of the index.
+func (g *generator) user(i int) models.User {
+	r := g.usersRand
+
+	registration := time.Duration(r.Int64N(int64(registrationYears * 365 * 24 * time.Hour)))
+	createdAt := g.opts.referenceTime.Add(-registration).Truncate(time.Second)
+	updatedAt := createdAt.Add(time.Duration(r.Int64N(int64(registration) + 1))).Truncate(time.Second)
+	g.createdAt[i] = createdAt.Unix()
+
+	var gender null.Int16
+	var firstNames []string
+	switch x := r.Float64(); {
+	case x < noGenderShare:
+		firstNames = maleFirstNames
+		if r.IntN(2) == 0 {
+			firstNames = femaleFirstNames
+		}
+	case x < noGenderShare+(1-noGenderShare)/2:
+		gender = null.Int16From(int16(enum.MALE))
+		firstNames = maleFirstNames
+	default:
+		gender = null.Int16From(int16(enum.FEMALE))
+		firstNames = femaleFirstNames
+	}
+	fullName := firstNames[r.IntN(len(firstNames))] + " " + lastNames[r.IntN(len(lastNames))]
+
+	var dateOfBirth null.Time
+	if r.Float64() >= noBirthDateShare {
+		age := min(minAge+r.ExpFloat64()*meanAgeAboveMin, maxAge)
+		dateOfBirth = null.TimeFrom(g.opts.referenceTime.
+			Add(-time.Duration(age * 365.25 * 24 * float64(time.Hour))).
+			Truncate(24 * time.Hour))
+	}
+
+	var avatarFileKey null.String
+	if r.Float64() < g.opts.avatarShare {
+		avatarFileKey = null.StringFrom(fmt.Sprintf("avatars/%016x.jpg", r.Uint64()))
+	}
+
+	return models.User{
+		ExternalID:    g.opts.externalIDStart + int64(i),
+		FullName:      fullName,
+		DateOfBirth:   dateOfBirth,
+		Gender:        gender,
+		AvatarFileKey: avatarFileKey,
+		Deleted:       r.Float64() < g.opts.deletedShare,
+		CreatedAt:     createdAt,
+		UpdatedAt:     updatedAt,
+	}
+}
+
+// follows generates the follow links of the user with the index i as pairs of user indexes,
+// including the links back to the user from the followed users that follow them back.
+// Links back can repeat links generated for other users; they are skipped on insert.
+// All users must be generated before the follows.
+func (g *generator) follows(i int, seen map[int]struct{}, emit func(following, followed int, f models.Follow)) {
+	r := g.followsRand
+	n := g.opts.users
+	if n < 2 {
+		return
+	}
+
+	mu := math.Log(g.opts.followsPerUser) - outDegreeSigma*outDegreeSigma/2
+	degree := min(int(math.Exp(mu+outDegreeSigma*r.NormFloat64())), g.opts.maxFollows, n-1)
+
+	clear(seen)
+	// Popular users are picked again and again by the most active users, so the attempts are limited.
+	for attempts := 0; len(seen) < degree && attempts < 3*degree; attempts++ {
+		rank := min(int(float64(n)*math.Pow(r.Float64(), g.rankPower)), n-1)
+		target := g.popularity[rank]
+		if target == i {
+			continue
+		}
+		if _, ok := seen[target]; ok {
+			continue
+		}
+		seen[target] = struct{}{}
+
+		emit(i, target, g.follow(i, target))
+		if r.Float64() < g.opts.mutualShare {
+			emit(target, i, g.follow(target, i))
+		}
+	}
+}
+
+// follow generates the data of the follow link between the users with the indexes.
+func (g *generator) follow(following, followed int) models.Follow {
+	r := g.followsRand
+
+	since := max(g.createdAt[following], g.createdAt[followed])
+	createdAt := time.Unix(since+r.Int64N(g.opts.referenceTime.Unix()-since+1), 0)
+
+	var likes uint32
+	if r.Float64() < g.opts.likedShare {
+		likes = 1 + uint32(math.Round(r.ExpFloat64()*(g.opts.meanLikes-1)))
+	}
+
+	return models.Follow{
+		NumberOfLikes: likes,
+		CreatedAt:     createdAt,
+		UpdatedAt:     createdAt,
+	}
+}
@@ -0,0 +1,182 @@
+// Command usersseed fills a migrated database with synthetic users and a follow graph for performance testing.
+// The data are deterministic: the same seed and flags give the same rows in an empty database.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"love-signal-users/internal/infrastructure/storage/models"
+	"love-signal-users/internal/infrastructure/storage/sqlite"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+)
+
+func main() {
+	var storagePath, referenceDate string
+	var seed uint64
+	var batchSize int
+	var opts options
+
+	flag.StringVar(&storagePath, "storage-path", "", "path to storage, migrated and preferably empty")
+	flag.Uint64Var(&seed, "seed", 1, "seed of the generated data")
+	flag.IntVar(&opts.users, "users", 10000, "number of users")
+	flag.Float64Var(&opts.followsPerUser, "follows-per-user", 20, "mean number of users followed by a user, not counting follow-backs")
+	flag.IntVar(&opts.maxFollows, "max-follows", 5000, "maximum number of users followed by a user, not counting follow-backs")
+	flag.Float64Var(&opts.inDegreeExp, "in-degree-exponent", 2.5, "power-law exponent of the numbers of followers, greater than 2")
+	flag.Float64Var(&opts.mutualShare, "mutual-share", 0.3, "share of follows that are followed back")
+	flag.Float64Var(&opts.likedShare, "liked-share", 0.4, "share of follows with likes")
+	flag.Float64Var(&opts.meanLikes, "mean-likes", 5, "mean number of likes of follows with likes, at least 1")
+	flag.Float64Var(&opts.deletedShare, "deleted-share", 0.01, "share of deleted users")
+	flag.Float64Var(&opts.avatarShare, "avatar-share", 0.7, "share of users with an avatar")
+	flag.Int64Var(&opts.externalIDStart, "external-id-start", 1, "external ID of the first user; the next ones are consecutive")
+	flag.StringVar(&referenceDate, "reference-date", "2026-01-01", "date the generated dates are relative to")
+	flag.IntVar(&batchSize, "batch-size", 10000, "number of rows inserted in one transaction")
+	flag.Parse()
+
+	if storagePath == "" {
+		panic("storage-path is required")
+	}
+
+	referenceTime, err := time.Parse(time.DateOnly, referenceDate)
+	if err != nil {
+		panic(fmt.Sprintf("invalid reference-date: %v", err))
+	}
+	opts.referenceTime = referenceTime
+
+	if err = validate(opts, batchSize); err != nil {
+		panic(err)
+	}
+
+	storage, err := sqlite.New(storagePath)
+	if err != nil {
+		panic(err)
+	}
+	defer func() {
+		_ = storage.Close()
+	}()
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	if err = seedStorage(ctx, storage, newGenerator(seed, opts), batchSize); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+// validate checks that the options make sense, reporting all the invalid ones.
+func validate(opts options, batchSize int) error {
+	var errs []error
+	if opts.users < 0 {
+		errs = append(errs, errors.New("users must not be negative"))
+	}
+	if opts.followsPerUser <= 0 {
+		errs = append(errs, errors.New("follows-per-user must be positive"))
+	}
+	if opts.maxFollows < 0 {
+		errs = append(errs, errors.New("max-follows must not be negative"))
+	}
+	if opts.inDegreeExp <= 2 {
+		errs = append(errs, errors.New("in-degree-exponent must be greater than 2"))
+	}
+	if opts.meanLikes < 1 {
+		errs = append(errs, errors.New("mean-likes must be at least 1"))
+	}
+	shares := []struct {
+		name  string
+		value float64
+	}{
+		{"mutual-share", opts.mutualShare},
+		{"liked-share", opts.likedShare},
+		{"deleted-share", opts.deletedShare},
+		{"avatar-share", opts.avatarShare},
+	}
+	for _, share := range shares {
+		if share.value < 0 || share.value > 1 {
+			errs = append(errs, fmt.Errorf("%s must be between 0 and 1", share.name))
+		}
+	}
+	if batchSize <= 0 {
+		errs = append(errs, errors.New("batch-size must be positive"))
+	}
+
+	return errors.Join(errs...)
+}
+
+// seedStorage inserts the users, then their follow links, in batches of one transaction each.
+func seedStorage(ctx context.Context, storage *sqlite.Storage, g *generator, batchSize int) error {
+	const op = "usersseed.seedStorage"
+
+	start := time.Now()
+
+	ids := make([]int64, 0, g.opts.users)
+	users := make([]models.User, 0, batchSize)
+	flushUsers := func() error {
+		inserted, err := storage.InsertUsers(ctx, users)
+		if err != nil {
+			return err
+		}
+		ids = append(ids, inserted...)
+		users = users[:0]
+
+		fmt.Printf("users: %d/%d\n", len(ids), g.opts.users)
+
+		return nil
+	}
+
+	for i := range g.opts.users {
+		users = append(users, g.user(i))
+		if len(users) == batchSize {
+			if err := flushUsers(); err != nil {
+				return fmt.Errorf("%s: %w", op, err)
+			}
+		}
+	}
+	if len(users) > 0 {
+		if err := flushUsers(); err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	var followsCount int64
+	follows := make([]models.Follow, 0, batchSize)
+	flushFollows := func(done int) error {
+		inserted, err := storage.InsertFollows(ctx, follows)
+		if err != nil {
+			return err
+		}
+		followsCount += inserted
+		follows = follows[:0]
+
+		fmt.Printf("follows: %d, of users %d/%d\n", followsCount, done, g.opts.users)
+
+		return nil
+	}
+
+	seen := make(map[int]struct{})
+	for i := range g.opts.users {
+		g.follows(i, seen, func(following, followed int, f models.Follow) {
+			f.FollowingUserID = ids[following]
+			f.FollowedUserID = ids[followed]
+			follows = append(follows, f)
+		})
+		if len(follows) >= batchSize {
+			if err := flushFollows(i + 1); err != nil {
+				return fmt.Errorf("%s: %w", op, err)
+			}
+		}
+	}
+	if len(follows) > 0 {
+		if err := flushFollows(g.opts.users); err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	fmt.Printf("seeded %d users and %d follows in %s\n", len(ids), followsCount, time.Since(start).Round(time.Millisecond))
+
+	return nil
+}
@@ -0,0 +1,24 @@
+package main
+
+var maleFirstNames = []string{
+	"Alexander", "Andrew", "Anton", "Arthur", "Benjamin", "Boris", "Carlos", "Daniel", "David", "Dmitry",
+	"Eduardo", "Egor", "Ethan", "Felix", "Gabriel", "George", "Hugo", "Ivan", "Jacob", "James",
+	"Kirill", "Leo", "Liam", "Lucas", "Marco", "Mark", "Matthew", "Maxim", "Michael", "Mikhail",
+	"Nathan", "Nikita", "Noah", "Oliver", "Oscar", "Pavel", "Peter", "Roman", "Samuel", "Sergey",
+	"Thomas", "Timur", "Victor", "William", "Yaroslav",
+}
+
+var femaleFirstNames = []string{
+	"Alice", "Alina", "Amelia", "Anastasia", "Anna", "Ava", "Camila", "Charlotte", "Chloe", "Daria",
+	"Diana", "Ekaterina", "Elena", "Ella", "Emily", "Emma", "Eva", "Grace", "Hannah", "Isabella",
+	"Julia", "Kristina", "Laura", "Lily", "Lucia", "Maria", "Marina", "Mia", "Natalia", "Olga",
+	"Olivia", "Polina", "Sofia", "Sophie", "Svetlana", "Tatiana", "Valeria", "Vera", "Victoria", "Zoe",
+}
+
+var lastNames = []string{
+	"Anderson", "Bauer", "Becker", "Brown", "Clark", "Costa", "Davis", "Dubois", "Fischer", "Garcia",
+	"Gonzalez", "Harris", "Hoffmann", "Ito", "Jackson", "Johnson", "Kim", "Klein", "Kowalski", "Kovacs",
+	"Lambert", "Lee", "Lopez", "Martin", "Martinez", "Meyer", "Miller", "Moore", "Moreau", "Nowak",
+	"Novak", "Olsen", "Peters", "Popescu", "Rossi", "Russo", "Sanchez", "Schmidt", "Schneider", "Silva",
+	"Smith", "Sato", "Taylor", "Thompson", "Weber", "Wagner", "Walker", "White", "Wilson", "Young",
+}
@@ -0,0 +1,284 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v5.29.1
+// source: events.proto
+
+package lseventspb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type FollowEventType int32
+
+const (
+	FollowEventType_FOLLOW_EVENT_TYPE_UNSPECIFIED  FollowEventType = 0
+	FollowEventType_FOLLOW_EVENT_TYPE_NEW_FOLLOWER FollowEventType = 1
+	FollowEventType_FOLLOW_EVENT_TYPE_UNFOLLOW     FollowEventType = 2
+	// Not delivered: the service has no like operations, so no event of this type is published.
+	FollowEventType_FOLLOW_EVENT_TYPE_LIKE_RECEIVED FollowEventType = 3
+	FollowEventType_FOLLOW_EVENT_TYPE_MATCH         FollowEventType = 4
+	FollowEventType_FOLLOW_EVENT_TYPE_KEEPALIVE     FollowEventType = 5
+)
+
+// Enum value maps for FollowEventType.
+var (
+	FollowEventType_name = map[int32]string{
+		0: "FOLLOW_EVENT_TYPE_UNSPECIFIED",
+		1: "FOLLOW_EVENT_TYPE_NEW_FOLLOWER",
+		2: "FOLLOW_EVENT_TYPE_UNFOLLOW",
+		3: "FOLLOW_EVENT_TYPE_LIKE_RECEIVED",
+		4: "FOLLOW_EVENT_TYPE_MATCH",
+		5: "FOLLOW_EVENT_TYPE_KEEPALIVE",
+	}
+	FollowEventType_value = map[string]int32{
+		"FOLLOW_EVENT_TYPE_UNSPECIFIED":   0,
+		"FOLLOW_EVENT_TYPE_NEW_FOLLOWER":  1,
+		"FOLLOW_EVENT_TYPE_UNFOLLOW":      2,
+		"FOLLOW_EVENT_TYPE_LIKE_RECEIVED": 3,
+		"FOLLOW_EVENT_TYPE_MATCH":         4,
+		"FOLLOW_EVENT_TYPE_KEEPALIVE":     5,
+	}
+)
+
+func (x FollowEventType) Enum() *FollowEventType {
+	p := new(FollowEventType)
+	*p = x
+	return p
+}
+
+func (x FollowEventType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (FollowEventType) Descriptor() protoreflect.EnumDescriptor {
+	return file_events_proto_enumTypes[0].Descriptor()
+}
+
+func (FollowEventType) Type() protoreflect.EnumType {
+	return &file_events_proto_enumTypes[0]
+}
+
+func (x FollowEventType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use FollowEventType.Descriptor instead.
+func (FollowEventType) EnumDescriptor() ([]byte, []int) {
+	return file_events_proto_rawDescGZIP(), []int{0}
+}
+
+type SubscribeFollowEventsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int64                  `protobuf:"varint,1,opt,name=userId,proto3" json:"userId,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubscribeFollowEventsRequest) Reset() {
+	*x = SubscribeFollowEventsRequest{}
+	mi := &file_events_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubscribeFollowEventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribeFollowEventsRequest) ProtoMessage() {}
+
+func (x *SubscribeFollowEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_events_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscribeFollowEventsRequest.ProtoReflect.Descriptor instead.
+func (*SubscribeFollowEventsRequest) Descriptor() ([]byte, []int) {
+	return file_events_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *SubscribeFollowEventsRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+type FollowEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          FollowEventType        `protobuf:"varint,1,opt,name=type,proto3,enum=events.FollowEventType" json:"type,omitempty"`
+	UserId        int64                  `protobuf:"varint,2,opt,name=userId,proto3" json:"userId,omitempty"`
+	ActorUserId   int64                  `protobuf:"varint,3,opt,name=actorUserId,proto3" json:"actorUserId,omitempty"`
+	FollowLinkId  int64                  `protobuf:"varint,4,opt,name=followLinkId,proto3" json:"followLinkId,omitempty"`
+	OccurredAt    *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=occurredAt,proto3" json:"occurredAt,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FollowEvent) Reset() {
+	*x = FollowEvent{}
+	mi := &file_events_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FollowEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FollowEvent) ProtoMessage() {}
+
+func (x *FollowEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_events_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FollowEvent.ProtoReflect.Descriptor instead.
+func (*FollowEvent) Descriptor() ([]byte, []int) {
+	return file_events_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *FollowEvent) GetType() FollowEventType {
+	if x != nil {
+		return x.Type
+	}
+	return FollowEventType_FOLLOW_EVENT_TYPE_UNSPECIFIED
+}
+
+func (x *FollowEvent) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *FollowEvent) GetActorUserId() int64 {
+	if x != nil {
+		return x.ActorUserId
+	}
+	return 0
+}
+
+func (x *FollowEvent) GetFollowLinkId() int64 {
+	if x != nil {
+		return x.FollowLinkId
+	}
+	return 0
+}
+
+func (x *FollowEvent) GetOccurredAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.OccurredAt
+	}
+	return nil
+}
+
+var File_events_proto protoreflect.FileDescriptor
+
+const file_events_proto_rawDesc = "" +
+	"\n" +
+	"\fevents.proto\x12\x06events\x1a\x1fgoogle/protobuf/timestamp.proto\"6\n" +
+	"\x1cSubscribeFollowEventsRequest\x12\x16\n" +
+	"\x06userId\x18\x01 \x01(\x03R\x06userId\"\xd4\x01\n" +
+	"\vFollowEvent\x12+\n" +
+	"\x04type\x18\x01 \x01(\x0e2\x17.events.FollowEventTypeR\x04type\x12\x16\n" +
+	"\x06userId\x18\x02 \x01(\x03R\x06userId\x12 \n" +
+	"\vactorUserId\x18\x03 \x01(\x03R\vactorUserId\x12\"\n" +
+	"\ffollowLinkId\x18\x04 \x01(\x03R\ffollowLinkId\x12:\n" +
+	"\n" +
+	"occurredAt\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"occurredAt*\xdb\x01\n" +
+	"\x0fFollowEventType\x12!\n" +
+	"\x1dFOLLOW_EVENT_TYPE_UNSPECIFIED\x10\x00\x12\"\n" +
+	"\x1eFOLLOW_EVENT_TYPE_NEW_FOLLOWER\x10\x01\x12\x1e\n" +
+	"\x1aFOLLOW_EVENT_TYPE_UNFOLLOW\x10\x02\x12#\n" +
+	"\x1fFOLLOW_EVENT_TYPE_LIKE_RECEIVED\x10\x03\x12\x1b\n" +
+	"\x17FOLLOW_EVENT_TYPE_MATCH\x10\x04\x12\x1f\n" +
+	"\x1bFOLLOW_EVENT_TYPE_KEEPALIVE\x10\x052d\n" +
+	"\fFollowEvents\x12T\n" +
+	"\x15SubscribeFollowEvents\x12$.events.SubscribeFollowEventsRequest\x1a\x13.events.FollowEvent0\x01B,Z*love-signal-users/gen/go/events;lseventspbb\x06proto3"
+
+var (
+	file_events_proto_rawDescOnce sync.Once
+	file_events_proto_rawDescData []byte
+)
+
+func file_events_proto_rawDescGZIP() []byte {
+	file_events_proto_rawDescOnce.Do(func() {
+		file_events_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_events_proto_rawDesc), len(file_events_proto_rawDesc)))
+	})
+	return file_events_proto_rawDescData
+}
+
+var file_events_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_events_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_events_proto_goTypes = []any{
+	(FollowEventType)(0),                 // 0: events.FollowEventType
+	(*SubscribeFollowEventsRequest)(nil), // 1: events.SubscribeFollowEventsRequest
+	(*FollowEvent)(nil),                  // 2: events.FollowEvent
+	(*timestamppb.Timestamp)(nil),        // 3: google.protobuf.Timestamp
+}
+var file_events_proto_depIdxs = []int32{
+	0, // 0: events.FollowEvent.type:type_name -> events.FollowEventType
+	3, // 1: events.FollowEvent.occurredAt:type_name -> google.protobuf.Timestamp
+	1, // 2: events.FollowEvents.SubscribeFollowEvents:input_type -> events.SubscribeFollowEventsRequest
+	2, // 3: events.FollowEvents.SubscribeFollowEvents:output_type -> events.FollowEvent
+	3, // [3:4] is the sub-list for method output_type
+	2, // [2:3] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_events_proto_init() }
+func file_events_proto_init() {
+	if File_events_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_events_proto_rawDesc), len(file_events_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_events_proto_goTypes,
+		DependencyIndexes: file_events_proto_depIdxs,
+		EnumInfos:         file_events_proto_enumTypes,
+		MessageInfos:      file_events_proto_msgTypes,
+	}.Build()
+	File_events_proto = out.File
+	file_events_proto_goTypes = nil
+	file_events_proto_depIdxs = nil
+}
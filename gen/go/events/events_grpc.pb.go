@@ -0,0 +1,128 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v5.29.1
+// source: events.proto
+
+package lseventspb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	FollowEvents_SubscribeFollowEvents_FullMethodName = "/events.FollowEvents/SubscribeFollowEvents"
+)
+
+// FollowEventsClient is the client API for FollowEvents service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// FollowEvents streams the follow events of a user. Calls are allowed only to the callers listed in the server config.
+type FollowEventsClient interface {
+	SubscribeFollowEvents(ctx context.Context, in *SubscribeFollowEventsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[FollowEvent], error)
+}
+
+type followEventsClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewFollowEventsClient(cc grpc.ClientConnInterface) FollowEventsClient {
+	return &followEventsClient{cc}
+}
+
+func (c *followEventsClient) SubscribeFollowEvents(ctx context.Context, in *SubscribeFollowEventsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[FollowEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &FollowEvents_ServiceDesc.Streams[0], FollowEvents_SubscribeFollowEvents_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[SubscribeFollowEventsRequest, FollowEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type FollowEvents_SubscribeFollowEventsClient = grpc.ServerStreamingClient[FollowEvent]
+
+// FollowEventsServer is the server API for FollowEvents service.
+// All implementations must embed UnimplementedFollowEventsServer
+// for forward compatibility.
+//
+// FollowEvents streams the follow events of a user. Calls are allowed only to the callers listed in the server config.
+type FollowEventsServer interface {
+	SubscribeFollowEvents(*SubscribeFollowEventsRequest, grpc.ServerStreamingServer[FollowEvent]) error
+	mustEmbedUnimplementedFollowEventsServer()
+}
+
+// UnimplementedFollowEventsServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedFollowEventsServer struct{}
+
+func (UnimplementedFollowEventsServer) SubscribeFollowEvents(*SubscribeFollowEventsRequest, grpc.ServerStreamingServer[FollowEvent]) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeFollowEvents not implemented")
+}
+func (UnimplementedFollowEventsServer) mustEmbedUnimplementedFollowEventsServer() {}
+func (UnimplementedFollowEventsServer) testEmbeddedByValue()                      {}
+
+// UnsafeFollowEventsServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to FollowEventsServer will
+// result in compilation errors.
+type UnsafeFollowEventsServer interface {
+	mustEmbedUnimplementedFollowEventsServer()
+}
+
+func RegisterFollowEventsServer(s grpc.ServiceRegistrar, srv FollowEventsServer) {
+	// If the following call pancis, it indicates UnimplementedFollowEventsServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&FollowEvents_ServiceDesc, srv)
+}
+
+func _FollowEvents_SubscribeFollowEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeFollowEventsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(FollowEventsServer).SubscribeFollowEvents(m, &grpc.GenericServerStream[SubscribeFollowEventsRequest, FollowEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type FollowEvents_SubscribeFollowEventsServer = grpc.ServerStreamingServer[FollowEvent]
+
+// FollowEvents_ServiceDesc is the grpc.ServiceDesc for FollowEvents service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var FollowEvents_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "events.FollowEvents",
+	HandlerType: (*FollowEventsServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "SubscribeFollowEvents",
+			Handler:       _FollowEvents_SubscribeFollowEvents_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "events.proto",
+}
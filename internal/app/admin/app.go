@@ -0,0 +1,90 @@
+package adminapp
+
+import (
+	"log/slog"
+	"love-signal-users/internal/config"
+	"love-signal-users/internal/controller/http/admin"
+	"love-signal-users/pkg/httpserver"
+	"love-signal-users/pkg/logger/sl"
+	"net"
+)
+
+// App is an admin application serving pprof, probes, build info and config reload.
+type App struct {
+	log        *slog.Logger
+	address    string
+	httpServer *httpserver.Server
+}
+
+// New creates new admin application.
+func New(
+	log *slog.Logger,
+	cfg config.AdminConfig,
+	storage admin.Storage,
+	readiness admin.Readiness,
+	reloader admin.Reloader,
+) *App {
+	router := admin.NewRouter(storage, readiness, reloader, cfg.MigrationsTable, cfg.ReadyTimeout)
+
+	httpServer := httpserver.New(
+		router,
+		httpserver.WithHost(cfg.Host),
+		httpserver.WithPort(cfg.Port),
+		// Profiles and traces are written for longer than the default write timeout.
+		httpserver.WithWriteTimeout(0),
+	)
+
+	return &App{
+		log:        log,
+		address:    net.JoinHostPort(cfg.Host, cfg.Port),
+		httpServer: httpServer,
+	}
+}
+
+// Start - starts the admin application.
+func (a *App) Start() {
+	const op = "adminapp.Start"
+
+	log := a.log.With(
+		slog.String("op", op),
+		slog.String("address", a.address),
+	)
+	log.Info("running admin server")
+
+	a.httpServer.Start()
+}
+
+// Stop - stops the admin application.
+func (a *App) Stop() {
+	const op = "adminapp.Stop"
+
+	log := a.log.With(
+		slog.String("op", op),
+		slog.String("address", a.address),
+	)
+	log.Info("stopping admin server")
+
+	if err := a.httpServer.Stop(); err != nil {
+		log.Error("error stopping admin server", sl.Err(err))
+	}
+}
+
+// ForceStop - stops the admin application immediately, closing active calls; a Stop in progress returns.
+func (a *App) ForceStop() {
+	const op = "adminapp.ForceStop"
+
+	log := a.log.With(
+		slog.String("op", op),
+		slog.String("address", a.address),
+	)
+	log.Warn("stopping admin server forcibly")
+
+	if err := a.httpServer.ForceStop(); err != nil {
+		log.Error("error stopping admin server forcibly", sl.Err(err))
+	}
+}
+
+// Notify - notifies about admin application errors.
+func (a *App) Notify() <-chan error {
+	return a.httpServer.Notify()
+}
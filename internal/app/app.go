@@ -1,38 +1,134 @@
 package app
 
 import (
+	"context"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"log/slog"
+	adminapp "love-signal-users/internal/app/admin"
 	grpcapp "love-signal-users/internal/app/grpc"
+	httpapp "love-signal-users/internal/app/http"
+	metricsapp "love-signal-users/internal/app/metrics"
 	"love-signal-users/internal/config"
+	"love-signal-users/internal/entity"
+	auditrecorder "love-signal-users/internal/infrastructure/audit"
 	"love-signal-users/internal/infrastructure/repository"
 	"love-signal-users/internal/infrastructure/storage/sqlite"
+	"love-signal-users/internal/metrics"
+	"love-signal-users/internal/usecase/deactivate"
 	"love-signal-users/internal/usecase/externaluser"
 	"love-signal-users/internal/usecase/follow"
 	"love-signal-users/internal/usecase/followed"
+	"love-signal-users/internal/usecase/followevents"
+	"love-signal-users/internal/usecase/interesttags"
+	"love-signal-users/internal/usecase/restore"
+	"love-signal-users/internal/usecase/stats"
 	"love-signal-users/internal/usecase/unfollow"
+	"love-signal-users/internal/usecase/updateprofile"
 	"love-signal-users/internal/usecase/user"
+	"love-signal-users/internal/usecase/userarchive"
+	"love-signal-users/internal/usecase/userprofile"
+	"love-signal-users/pkg/audit"
+	"love-signal-users/pkg/eventbus"
+	"love-signal-users/pkg/featureflag"
+	"love-signal-users/pkg/grpcserver"
+	"love-signal-users/pkg/logger"
 	"love-signal-users/pkg/logger/sl"
+	"love-signal-users/pkg/tracing"
 	"os"
 	"os/signal"
+	"sync"
+	"sync/atomic"
 	"syscall"
+	"time"
 )
 
+// forcedExitCode is the exit code when the shutdown is interrupted by a second signal.
+const forcedExitCode = 1
+
 // App is an application.
 type App struct {
-	log     *slog.Logger
-	grpcApp *grpcapp.App
+	log        *slog.Logger
+	grpcApp    *grpcapp.App
+	httpApp    *httpapp.App
+	metricsApp *metricsapp.App
+	adminApp   *adminapp.App
+	lifecycle  lifecycle
+	ready      atomic.Bool
+
+	// Config reload: the config on start, the last applied config and the levels of the log sinks.
+	reloadMutex sync.Mutex
+	loadConfig  ConfigLoader
+	startCfg    *config.Config
+	cfg         *config.Config
+	logLevels   *logger.Levels
+	flags       *featureflag.Registry
 }
 
 // New creates a new application.
+// The config loader and the levels of the logger are used to reload the config.
 func New(
 	log *slog.Logger,
 	cfg *config.Config,
+	loadConfig ConfigLoader,
+	logLevels *logger.Levels,
 ) *App {
+	// Metrics.
+	appMetrics := metrics.New()
+
+	// Tracing.
+	storageHooks := []sqlite.Hook{appMetrics.StorageHook()}
+	var grpcTracingOpts []grpcserver.Option
+	var shutdownTracing func(context.Context) error
+	if cfg.Tracing.Enabled {
+		var err error
+		shutdownTracing, err = tracing.Setup(context.Background(), tracing.Config{
+			ServiceName:  cfg.Tracing.ServiceName,
+			OTLPEndpoint: cfg.Tracing.OTLPEndpoint,
+			OTLPInsecure: cfg.Tracing.OTLPInsecure,
+			File:         cfg.Tracing.File,
+			SampleRatio:  cfg.Tracing.SampleRatio,
+		})
+		if err != nil {
+			panic(err)
+		}
+
+		storageHooks = append(storageHooks, tracing.OperationHook)
+		grpcTracingOpts = append(grpcTracingOpts, grpcserver.WithStatsHandler(otelgrpc.NewServerHandler()))
+	}
+
 	// Storages.
-	storage, err := sqlite.New(cfg.StoragePath)
+	storageOpts := []sqlite.Option{sqlite.WithHooks(storageHooks...)}
+	if cfg.SlowQuery.Enabled {
+		storageOpts = append(storageOpts, sqlite.WithSlowQueryLog(log, cfg.SlowQuery.Threshold, cfg.SlowQuery.Explain))
+	}
+
+	storage, err := sqlite.New(cfg.StoragePath, storageOpts...)
 	if err != nil {
 		panic(err)
 	}
+	appMetrics.RegisterDBStats(storage)
+
+	// Event buses.
+	followEventBus := eventbus.New[int64, entity.FollowEvent](cfg.FollowEvents.BufferSize)
+	followEventPublisher := appMetrics.CountingPublisher(followEventBus)
+
+	// Audit log.
+	var auditLog *audit.Log
+	if cfg.Audit.Enabled {
+		auditLog, err = audit.Open(cfg.Audit.Path)
+		if err != nil {
+			panic(err)
+		}
+	}
+	auditRecorder := auditrecorder.NewRecorder(log, auditLog)
+	auditHistoryPath := ""
+	if cfg.Audit.Enabled {
+		auditHistoryPath = cfg.Audit.Path
+	}
+	auditHistory := auditrecorder.NewHistory(auditHistoryPath)
+
+	// Feature flags.
+	flags := featureflag.NewRegistry(featureFlags(cfg.FeatureFlags))
 
 	// Repositories.
 	usersRepository := repository.NewUsersRepository(log, storage)
@@ -41,23 +137,115 @@ func New(
 	userDataUseCase := user.New(log, usersRepository)
 	userDataByExternalIDUseCase := externaluser.New(log, usersRepository)
 	followedUsersUseCase := followed.New(log, usersRepository)
-	followUserUseCase := follow.New(log, usersRepository)
-	unfollowUserUseCase := unfollow.New(log, usersRepository)
+	followUserUseCase := follow.New(log, usersRepository, followEventPublisher, auditRecorder)
+	unfollowUserUseCase := unfollow.New(log, usersRepository, followEventPublisher, auditRecorder)
+	followEventsUseCase := followevents.New(log, usersRepository, followEventBus)
+	deactivateUserUseCase := deactivate.New(log, usersRepository, auditRecorder)
+	restoreUserUseCase := restore.New(log, usersRepository, auditRecorder)
+	statsUseCase := stats.New(log, usersRepository)
+	userArchiveUseCase := userarchive.New(log, usersRepository, auditHistory, auditRecorder)
+	userProfileUseCase := userprofile.New(log, usersRepository)
+	updateUserProfileUseCase := updateprofile.New(log, usersRepository, auditRecorder)
+	interestTagsUseCase := interesttags.New(log, usersRepository)
 
 	grpcApp := grpcapp.New(
 		log,
-		cfg.GRPC.Port,
+		cfg.GRPC,
 		userDataUseCase,
 		userDataByExternalIDUseCase,
 		followedUsersUseCase,
 		followUserUseCase,
 		unfollowUserUseCase,
+		followEventsUseCase,
+		cfg.FollowEvents.KeepaliveInterval,
+		deactivateUserUseCase,
+		restoreUserUseCase,
+		statsUseCase,
+		userArchiveUseCase,
+		append(
+			grpcTracingOpts,
+			grpcserver.WithRequestLogger(log),
+			grpcserver.WithUnaryInterceptors(appMetrics.UnaryServerInterceptor(), flags.UnaryServerInterceptor()),
+			grpcserver.WithStreamInterceptors(appMetrics.StreamServerInterceptor(), flags.StreamServerInterceptor()),
+		)...,
 	)
 
-	return &App{
-		log:     log,
-		grpcApp: grpcApp,
+	var httpApp *httpapp.App
+	if cfg.HTTP.Enabled {
+		httpApp = httpapp.New(
+			log,
+			cfg.HTTP,
+			flags,
+			userDataUseCase,
+			userDataByExternalIDUseCase,
+			followedUsersUseCase,
+			followUserUseCase,
+			unfollowUserUseCase,
+			userProfileUseCase,
+			updateUserProfileUseCase,
+			interestTagsUseCase,
+		)
 	}
+
+	var metricsApp *metricsapp.App
+	if cfg.Metrics.Enabled {
+		metricsApp = metricsapp.New(log, cfg.Metrics, appMetrics.Handler())
+	}
+
+	a := &App{
+		log:        log,
+		grpcApp:    grpcApp,
+		httpApp:    httpApp,
+		metricsApp: metricsApp,
+		lifecycle:  lifecycle{log: log, forceStopTimeout: forceStopTimeout},
+
+		loadConfig: loadConfig,
+		startCfg:   cfg,
+		cfg:        cfg,
+		logLevels:  logLevels,
+		flags:      flags,
+	}
+
+	if cfg.Admin.Enabled {
+		a.adminApp = adminapp.New(log, cfg.Admin, storage, a, a)
+	}
+
+	// Components are stopped in reverse order: servers first, then the resources they use.
+	a.lifecycle.add("storage", nil, func(context.Context) error {
+		return storage.Close()
+	}, nil)
+
+	if shutdownTracing != nil {
+		// Spans of the last calls are flushed after the servers have stopped.
+		a.lifecycle.add("tracing", nil, shutdownTracing, nil)
+	}
+
+	if auditLog != nil {
+		// The audit log is closed after the servers have stopped, so that calls being drained are recorded.
+		a.lifecycle.add("audit log", nil, func(context.Context) error {
+			return auditLog.Close()
+		}, nil)
+	}
+
+	// The admin server is stopped last of the servers, so that probes and profiles are available while the others stop.
+	if a.adminApp != nil {
+		a.lifecycle.add("admin server", a.adminApp.Start, stopFunc(a.adminApp.Stop), a.adminApp.ForceStop)
+	}
+
+	if metricsApp != nil {
+		a.lifecycle.add("metrics server", metricsApp.Start, stopFunc(metricsApp.Stop), metricsApp.ForceStop)
+	}
+
+	if httpApp != nil {
+		a.lifecycle.add("HTTP server", httpApp.Start, stopFunc(httpApp.Stop), httpApp.ForceStop)
+	}
+
+	a.lifecycle.add("gRPC server", grpcApp.Start, stopFunc(grpcApp.Stop), grpcApp.ForceStop)
+
+	// Event subscriptions are long-lived streams, so they are ended before the gRPC server waits for calls to finish.
+	a.lifecycle.add("follow events", nil, stopFunc(followEventBus.Close), nil)
+
+	return a
 }
 
 // Start - starts the application.
@@ -67,26 +255,125 @@ func (a *App) Start() {
 	log := a.log.With(slog.String("op", op))
 	log.Info("starting application")
 
-	a.grpcApp.Start()
+	a.lifecycle.start()
+	a.ready.Store(true)
+}
+
+// Ready reports whether the application serves calls: it has started and is not shutting down.
+func (a *App) Ready() bool {
+	return a.ready.Load()
 }
 
-// GracefulStop - gracefully stops the application.
+// GracefulStop - gracefully stops the application on SIGTERM, SIGINT or a server error.
+// SIGHUP reloads the config instead. The readiness probe fails first; after the readiness delay,
+// the components are stopped in reverse order within the shutdown timeout; servers still stopping after it
+// are stopped forcibly. A second SIGTERM or SIGINT during the shutdown exits immediately.
 func (a *App) GracefulStop() {
 	const op = "app.GracefulStop"
 
 	log := a.log.With(slog.String("op", op))
 
-	stop := make(chan os.Signal, 1)
-	signal.Notify(stop, syscall.SIGTERM, syscall.SIGINT)
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGTERM, syscall.SIGINT, syscall.SIGHUP)
 
-	select {
-	case s := <-stop:
-		log.Info("signal received from OS", slog.String("signal:", s.String()))
-	case err := <-a.grpcApp.Notify():
-		log.Error("received an error from the gRPC server:", sl.Err(err))
-	}
+	a.wait(log, signals)
+
+	go forceExit(log, signals)
 
 	log.Info("stopping application")
 
-	a.grpcApp.Stop()
+	ctx, cancel := context.WithTimeout(context.Background(), a.startCfg.Shutdown.Timeout)
+	defer cancel()
+
+	// A second signal during the delay or the stop is handled by forceExit.
+	a.ready.Store(false)
+	if delay := a.startCfg.Shutdown.ReadinessDelay; delay > 0 {
+		log.Info("readiness is off, waiting before draining", slog.Duration("delay", delay))
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+		}
+	}
+
+	if err := a.lifecycle.stop(ctx); err != nil {
+		log.Error("application was not stopped cleanly", sl.Err(err))
+	}
+}
+
+// forceExit exits immediately on a shutdown signal received during the shutdown.
+func forceExit(log *slog.Logger, signals <-chan os.Signal) {
+	for s := range signals {
+		if s == syscall.SIGHUP {
+			continue
+		}
+
+		log.Warn("second signal received from OS, exiting immediately", slog.String("signal:", s.String()))
+		os.Exit(forcedExitCode)
+	}
+}
+
+// stopFunc adapts the stop function without context and error to the stop hook of a component.
+// Such functions log their errors and are bounded by their own timeouts.
+func stopFunc(stop func()) func(context.Context) error {
+	return func(context.Context) error {
+		stop()
+		return nil
+	}
+}
+
+// wait waits for a shutdown signal or a server error, reloading the config on SIGHUP.
+func (a *App) wait(log *slog.Logger, signals <-chan os.Signal) {
+	for {
+		select {
+		case s := <-signals:
+			if s == syscall.SIGHUP {
+				log.Info("signal received from OS, reloading config", slog.String("signal:", s.String()))
+				// Reload logs its result itself.
+				_, _, _ = a.Reload()
+				continue
+			}
+
+			log.Info("signal received from OS", slog.String("signal:", s.String()))
+			return
+		case err := <-a.grpcApp.Notify():
+			log.Error("received an error from the gRPC server:", sl.Err(err))
+			return
+		case err := <-a.httpNotify():
+			log.Error("received an error from the HTTP server:", sl.Err(err))
+			return
+		case err := <-a.metricsNotify():
+			log.Error("received an error from the metrics server:", sl.Err(err))
+			return
+		case err := <-a.adminNotify():
+			log.Error("received an error from the admin server:", sl.Err(err))
+			return
+		}
+	}
+}
+
+// httpNotify returns the channel of HTTP server errors, or nil channel if the HTTP server is disabled.
+func (a *App) httpNotify() <-chan error {
+	if a.httpApp == nil {
+		return nil
+	}
+
+	return a.httpApp.Notify()
+}
+
+// metricsNotify returns the channel of metrics server errors, or nil channel if the metrics server is disabled.
+func (a *App) metricsNotify() <-chan error {
+	if a.metricsApp == nil {
+		return nil
+	}
+
+	return a.metricsApp.Notify()
+}
+
+// adminNotify returns the channel of admin server errors, or nil channel if the admin server is disabled.
+func (a *App) adminNotify() <-chan error {
+	if a.adminApp == nil {
+		return nil
+	}
+
+	return a.adminApp.Notify()
 }
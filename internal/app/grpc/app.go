@@ -1,30 +1,103 @@
 package grpcapp
 
 import (
+	"crypto/tls"
+	"fmt"
+	"google.golang.org/grpc/keepalive"
 	"log/slog"
+	lsadminpb "love-signal-users/gen/go/admin"
+	lseventspb "love-signal-users/gen/go/events"
+	"love-signal-users/internal/config"
 	"love-signal-users/internal/controller"
 	"love-signal-users/internal/controller/grpc"
 	"love-signal-users/pkg/grpcserver"
+	"love-signal-users/pkg/logger/sl"
+	"slices"
+	"time"
 )
 
 // App is an gRPC controller application.
 type App struct {
-	log        *slog.Logger
-	port       string
-	gRPCServer *grpcserver.Server
+	log         *slog.Logger
+	gRPCServer  *grpcserver.Server
+	timeouts    *grpcserver.Timeouts
+	rateLimiter *grpcserver.RateLimiter
 }
 
 // New creates new gRPC controller application.
 func New(
 	log *slog.Logger,
-	port string,
+	cfg config.GRPCConfig,
 	userDataUseCase controller.UserData,
 	userDataByExternalIDUseCase controller.UserDataByExternalID,
 	followedUsersUseCase controller.Followed,
 	followUserUseCase controller.Follow,
 	unfollowUserUseCase controller.Unfollow,
+	followEventsUseCase controller.FollowEvents,
+	followEventsKeepalive time.Duration,
+	deactivateUserUseCase controller.DeactivateUser,
+	restoreUserUseCase controller.RestoreUser,
+	statsUseCase controller.Stats,
+	userArchiveUseCase controller.UserArchive,
+	serverOpts ...grpcserver.Option,
 ) *App {
-	gRPCServer := grpcserver.New(grpcserver.WithPort(port))
+	// Server options from the caller go first, so that their interceptors also see calls rejected by the rate limiter.
+	// The options are copied, so that appending them does not write to the array of the caller.
+	opts := slices.Concat(serverOpts, []grpcserver.Option{
+		grpcserver.WithHost(cfg.Host),
+		grpcserver.WithPort(cfg.Port),
+		grpcserver.WithMaxRecvMsgSize(cfg.MaxRecvMsgSize),
+		grpcserver.WithMaxSendMsgSize(cfg.MaxSendMsgSize),
+		grpcserver.WithConnectionIdleTimeout(cfg.ConnectionIdleTimeout),
+		grpcserver.WithDrainTimeout(cfg.DrainTimeout),
+		grpcserver.WithKeepaliveParams(keepalive.ServerParameters{
+			Time:                  cfg.Keepalive.Time,
+			Timeout:               cfg.Keepalive.Timeout,
+			MaxConnectionAge:      cfg.Keepalive.MaxConnectionAge,
+			MaxConnectionAgeGrace: cfg.Keepalive.MaxConnectionAgeGrace,
+		}),
+		grpcserver.WithKeepaliveEnforcementPolicy(keepalive.EnforcementPolicy{
+			MinTime:             cfg.Keepalive.MinTime,
+			PermitWithoutStream: cfg.Keepalive.PermitWithoutStream,
+		}),
+	})
+
+	if cfg.UnixSocket != "" {
+		opts = append(opts, grpcserver.WithUnixSocket(cfg.UnixSocket))
+	}
+
+	if cfg.MaxConcurrentStreams > 0 {
+		opts = append(opts, grpcserver.WithMaxConcurrentStreams(cfg.MaxConcurrentStreams))
+	}
+
+	if cfg.Gzip {
+		opts = append(opts, grpcserver.WithGzip())
+	}
+
+	if cfg.TLS.Enabled {
+		tlsConfig, err := newTLSConfig(cfg.TLS)
+		if err != nil {
+			panic(err)
+		}
+
+		opts = append(opts, grpcserver.WithTLSConfig(tlsConfig))
+	}
+
+	opts = append(opts,
+		grpcserver.WithAllowedCallers(lsadminpb.Admin_ServiceDesc.ServiceName, cfg.AdminCallers),
+		grpcserver.WithAllowedCallers(lseventspb.FollowEvents_ServiceDesc.ServiceName, cfg.FollowEventsCallers),
+	)
+
+	var rateLimiter *grpcserver.RateLimiter
+	if cfg.RateLimit.Enabled {
+		rateLimiter = grpcserver.NewRateLimiter(rateLimits(cfg.RateLimit))
+		opts = append(opts, grpcserver.WithRateLimiter(rateLimiter))
+	}
+
+	timeouts := grpcserver.NewTimeouts(cfg.Timeout, cfg.MethodTimeouts)
+	opts = append(opts, grpcserver.WithTimeouts(timeouts))
+
+	gRPCServer := grpcserver.New(opts...)
 
 	grpc.NewRouter(
 		gRPCServer.App,
@@ -33,12 +106,19 @@ func New(
 		followedUsersUseCase,
 		followUserUseCase,
 		unfollowUserUseCase,
+		followEventsUseCase,
+		followEventsKeepalive,
+		deactivateUserUseCase,
+		restoreUserUseCase,
+		statsUseCase,
+		userArchiveUseCase,
 	)
 
 	return &App{
-		log:        log,
-		port:       port,
-		gRPCServer: gRPCServer,
+		log:         log,
+		gRPCServer:  gRPCServer,
+		timeouts:    timeouts,
+		rateLimiter: rateLimiter,
 	}
 }
 
@@ -48,7 +128,7 @@ func (a *App) Start() {
 
 	log := a.log.With(
 		slog.String("op", op),
-		slog.String("port", a.port),
+		slog.String("address", a.gRPCServer.Address()),
 	)
 	log.Info("running gRPC server")
 
@@ -61,14 +141,75 @@ func (a *App) Stop() {
 
 	log := a.log.With(
 		slog.String("op", op),
-		slog.String("port", a.port),
+		slog.String("address", a.gRPCServer.Address()),
 	)
 	log.Info("stopping gRPC server")
 
-	a.gRPCServer.Stop()
+	if err := a.gRPCServer.Stop(); err != nil {
+		log.Warn("gRPC server was not stopped gracefully", sl.Err(err))
+	}
+}
+
+// ForceStop - stops the gRPC controller application immediately, closing active calls; a Stop in progress returns.
+func (a *App) ForceStop() {
+	const op = "grpcapp.ForceStop"
+
+	log := a.log.With(
+		slog.String("op", op),
+		slog.String("address", a.gRPCServer.Address()),
+	)
+	log.Warn("stopping gRPC server forcibly")
+
+	a.gRPCServer.ForceStop()
 }
 
 // Notify - notifies about gRPC controller application errors.
 func (a *App) Notify() <-chan error {
 	return a.gRPCServer.Notify()
 }
+
+// Reload applies the settings that can be changed at runtime: call timeouts and rate limits.
+// Rate limits are applied only if rate limiting was enabled on start.
+func (a *App) Reload(cfg config.GRPCConfig) {
+	a.timeouts.Set(cfg.Timeout, cfg.MethodTimeouts)
+
+	if a.rateLimiter != nil {
+		a.rateLimiter.SetLimits(rateLimits(cfg.RateLimit))
+	}
+}
+
+// rateLimits returns the default and per-method limits of the rate limiting config.
+func rateLimits(cfg config.RateLimitConfig) (grpcserver.Limit, map[string]grpcserver.Limit) {
+	methodLimits := make(map[string]grpcserver.Limit, len(cfg.Methods))
+	for method, limit := range cfg.Methods {
+		methodLimits[method] = grpcserver.Limit{RPS: limit.RPS, Burst: limit.Burst}
+	}
+
+	defaultLimit := grpcserver.Limit{RPS: cfg.Default.RPS, Burst: cfg.Default.Burst}
+
+	return defaultLimit, methodLimits
+}
+
+func newTLSConfig(cfg config.TLSConfig) (*tls.Config, error) {
+	const op = "grpcapp.newTLSConfig"
+
+	minVersion, err := grpcserver.ParseTLSVersion(cfg.MinVersion)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	opts := []grpcserver.TLSOption{
+		grpcserver.WithMinTLSVersion(minVersion),
+	}
+
+	if cfg.ClientCAFile != "" {
+		opts = append(opts, grpcserver.WithClientCA(cfg.ClientCAFile))
+	}
+
+	tlsConfig, err := grpcserver.NewTLSConfig(cfg.CertFile, cfg.KeyFile, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return tlsConfig, nil
+}
@@ -0,0 +1,107 @@
+package httpapp
+
+import (
+	"log/slog"
+	"love-signal-users/internal/config"
+	"love-signal-users/internal/controller"
+	"love-signal-users/internal/controller/http"
+	"love-signal-users/pkg/featureflag"
+	"love-signal-users/pkg/httpserver"
+	"love-signal-users/pkg/logger/sl"
+)
+
+// App is an HTTP controller application.
+type App struct {
+	log        *slog.Logger
+	port       string
+	httpServer *httpserver.Server
+}
+
+// New creates new HTTP controller application. Requests read the current feature flags from their context.
+func New(
+	log *slog.Logger,
+	cfg config.HTTPConfig,
+	flags *featureflag.Registry,
+	userDataUseCase controller.UserData,
+	userDataByExternalIDUseCase controller.UserDataByExternalID,
+	followedUsersUseCase controller.Followed,
+	followUserUseCase controller.Follow,
+	unfollowUserUseCase controller.Unfollow,
+	userProfileUseCase controller.UserProfile,
+	updateUserProfileUseCase controller.UpdateUserProfile,
+	interestTagsUseCase controller.InterestTags,
+) *App {
+	router := http.NewRouter(
+		userDataUseCase,
+		userDataByExternalIDUseCase,
+		followedUsersUseCase,
+		followUserUseCase,
+		unfollowUserUseCase,
+		userProfileUseCase,
+		updateUserProfileUseCase,
+		interestTagsUseCase,
+	)
+
+	httpServer := httpserver.New(
+		flags.Middleware(router),
+		httpserver.WithRequestLogger(log),
+		httpserver.WithPort(cfg.Port),
+		httpserver.WithReadTimeout(cfg.ReadTimeout),
+		httpserver.WithWriteTimeout(cfg.WriteTimeout),
+		httpserver.WithShutdownTimeout(cfg.ShutdownTimeout),
+	)
+
+	return &App{
+		log:        log,
+		port:       cfg.Port,
+		httpServer: httpServer,
+	}
+}
+
+// Start - starts the HTTP controller application.
+func (a *App) Start() {
+	const op = "httpapp.Start"
+
+	log := a.log.With(
+		slog.String("op", op),
+		slog.String("port", a.port),
+	)
+	log.Info("running HTTP server")
+
+	a.httpServer.Start()
+}
+
+// Stop - stops the HTTP controller application.
+func (a *App) Stop() {
+	const op = "httpapp.Stop"
+
+	log := a.log.With(
+		slog.String("op", op),
+		slog.String("port", a.port),
+	)
+	log.Info("stopping HTTP server")
+
+	if err := a.httpServer.Stop(); err != nil {
+		log.Error("error stopping HTTP server", sl.Err(err))
+	}
+}
+
+// ForceStop - stops the HTTP controller application immediately, closing active calls; a Stop in progress returns.
+func (a *App) ForceStop() {
+	const op = "httpapp.ForceStop"
+
+	log := a.log.With(
+		slog.String("op", op),
+		slog.String("port", a.port),
+	)
+	log.Warn("stopping HTTP server forcibly")
+
+	if err := a.httpServer.ForceStop(); err != nil {
+		log.Error("error stopping HTTP server forcibly", sl.Err(err))
+	}
+}
+
+// Notify - notifies about HTTP controller application errors.
+func (a *App) Notify() <-chan error {
+	return a.httpServer.Notify()
+}
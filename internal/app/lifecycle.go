@@ -0,0 +1,136 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"love-signal-users/pkg/logger/sl"
+	"time"
+)
+
+// forceStopTimeout is how long a component is waited for after the shutdown deadline,
+// once it has been stopped forcibly, or to release its resources with the expired context.
+const forceStopTimeout = time.Second
+
+// component is a part of the application that is started and stopped with it.
+// Any hook may be nil, e.g. for resources opened on creation. The force stop hook makes a stop in progress return,
+// e.g. by closing the connections of a server.
+type component struct {
+	name      string
+	start     func()
+	stop      func(ctx context.Context) error
+	forceStop func()
+}
+
+// lifecycle starts the components in the order they were added and stops them in reverse order,
+// so that a component is stopped before the ones it depends on.
+type lifecycle struct {
+	log              *slog.Logger
+	components       []component
+	forceStopTimeout time.Duration
+}
+
+// add adds the component with its start, stop and force stop hooks.
+func (l *lifecycle) add(name string, start func(), stop func(ctx context.Context) error, forceStop func()) {
+	l.components = append(l.components, component{name: name, start: start, stop: stop, forceStop: forceStop})
+}
+
+// start starts the components.
+func (l *lifecycle) start() {
+	const op = "app.lifecycle.start"
+
+	log := l.log.With(slog.String("op", op))
+
+	for _, c := range l.components {
+		if c.start == nil {
+			continue
+		}
+
+		log.Debug("starting component", slog.String("component", c.name))
+		c.start()
+	}
+}
+
+// stop stops the components in reverse order until the deadline of the context.
+// A component that has not stopped by the deadline is stopped forcibly, if it can be; the rest are stopped
+// with the expired context, so that they release their resources without waiting.
+// A component that is still running after that is left behind, and the components added before it,
+// which it may use, are not stopped.
+func (l *lifecycle) stop(ctx context.Context) error {
+	const op = "app.lifecycle.stop"
+
+	log := l.log.With(slog.String("op", op))
+
+	var errs []error
+	running := ""
+	for i := len(l.components) - 1; i >= 0; i-- {
+		c := l.components[i]
+		if c.stop == nil {
+			continue
+		}
+
+		if running != "" {
+			log.Error("component not stopped, as a component that may use it is still running",
+				slog.String("component", c.name),
+				slog.String("running component", running),
+			)
+			errs = append(errs, fmt.Errorf("%s: not stopped, %s is still running", c.name, running))
+
+			continue
+		}
+
+		log.Debug("stopping component", slog.String("component", c.name))
+		stopped, err := l.stopComponent(ctx, c)
+		if err != nil {
+			log.Error("failed to stop component", slog.String("component", c.name), sl.Err(err))
+			errs = append(errs, fmt.Errorf("%s: %w", c.name, err))
+		}
+		if !stopped {
+			running = c.name
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// stopComponent calls the stop hook of the component and waits for it until the deadline of the context.
+// After the deadline, the component is stopped forcibly and waited for no longer than the force stop timeout.
+// It reports whether the stop hook has returned.
+func (l *lifecycle) stopComponent(ctx context.Context, c component) (bool, error) {
+	done := make(chan error, 1)
+	go func() {
+		done <- c.stop(ctx)
+	}()
+
+	select {
+	case err := <-done:
+		return true, err
+	case <-ctx.Done():
+	}
+
+	// The hook may have returned at the deadline, or before it if the context had expired already.
+	select {
+	case err := <-done:
+		return true, err
+	default:
+	}
+
+	if c.forceStop != nil {
+		c.forceStop()
+	}
+
+	timer := time.NewTimer(l.forceStopTimeout)
+	defer timer.Stop()
+
+	select {
+	case err := <-done:
+		if c.forceStop != nil {
+			return true, errors.Join(fmt.Errorf("stopped forcibly, shutdown deadline exceeded: %w", ctx.Err()), err)
+		}
+
+		return true, err
+	case <-timer.C:
+		return false, fmt.Errorf("still running, shutdown deadline exceeded: %w", ctx.Err())
+	}
+}
@@ -0,0 +1,111 @@
+package app
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// stopRecorder records the names of the stopped components in order.
+type stopRecorder struct {
+	mutex   sync.Mutex
+	stopped []string
+}
+
+func (r *stopRecorder) stop(name string) func(context.Context) error {
+	return func(context.Context) error {
+		r.mutex.Lock()
+		defer r.mutex.Unlock()
+
+		r.stopped = append(r.stopped, name)
+
+		return nil
+	}
+}
+
+func (r *stopRecorder) names() []string {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	return slices.Clone(r.stopped)
+}
+
+func newTestLifecycle() *lifecycle {
+	return &lifecycle{log: slog.New(slog.DiscardHandler), forceStopTimeout: 50 * time.Millisecond}
+}
+
+func Test_LifecycleStopsComponentsInReverseOrder(t *testing.T) {
+	recorder := &stopRecorder{}
+	l := newTestLifecycle()
+	l.add("storage", nil, recorder.stop("storage"), nil)
+	l.add("no stop hook", func() {}, nil, nil)
+	l.add("server", nil, recorder.stop("server"), nil)
+
+	if err := l.stop(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if expected := []string{"server", "storage"}; !slices.Equal(recorder.names(), expected) {
+		t.Errorf("expected stop order %v, got: %v", expected, recorder.names())
+	}
+}
+
+func Test_LifecycleForcesComponentAfterDeadline(t *testing.T) {
+	recorder := &stopRecorder{}
+	forced := make(chan struct{})
+
+	l := newTestLifecycle()
+	l.add("storage", nil, recorder.stop("storage"), nil)
+	l.add("server", nil, func(context.Context) error {
+		<-forced
+		return nil
+	}, func() {
+		close(forced)
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	err := l.stop(ctx)
+	if err == nil || !strings.Contains(err.Error(), "server: stopped forcibly") {
+		t.Errorf("expected server stopped forcibly, got: %v", err)
+	}
+	if expected := []string{"storage"}; !slices.Equal(recorder.names(), expected) {
+		t.Errorf("expected dependencies of forcibly stopped component to be stopped, got: %v", recorder.names())
+	}
+}
+
+func Test_LifecycleLeavesDependenciesOfRunningComponent(t *testing.T) {
+	recorder := &stopRecorder{}
+	release := make(chan struct{})
+	defer close(release)
+
+	l := newTestLifecycle()
+	l.add("storage", nil, recorder.stop("storage"), nil)
+	l.add("worker", nil, func(context.Context) error {
+		<-release
+		return nil
+	}, nil)
+	l.add("server", nil, recorder.stop("server"), nil)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	started := time.Now()
+	err := l.stop(ctx)
+	if elapsed := time.Since(started); elapsed > time.Second {
+		t.Errorf("expected stop to return after the deadline and the force stop timeout, took: %s", elapsed)
+	}
+
+	if err == nil || !strings.Contains(err.Error(), "worker: still running") ||
+		!strings.Contains(err.Error(), "storage: not stopped, worker is still running") {
+		t.Errorf("expected worker left behind and storage not stopped, got: %v", err)
+	}
+	if expected := []string{"server"}; !slices.Equal(recorder.names(), expected) {
+		t.Errorf("expected only components stopped before the running one, got: %v", recorder.names())
+	}
+}
@@ -0,0 +1,85 @@
+package metricsapp
+
+import (
+	"log/slog"
+	"love-signal-users/internal/config"
+	"love-signal-users/pkg/httpserver"
+	"love-signal-users/pkg/logger/sl"
+	"net/http"
+)
+
+// App is a metrics exposition application.
+type App struct {
+	log        *slog.Logger
+	port       string
+	httpServer *httpserver.Server
+}
+
+// New creates new metrics exposition application serving the metrics handler on the configured path.
+func New(
+	log *slog.Logger,
+	cfg config.MetricsConfig,
+	metricsHandler http.Handler,
+) *App {
+	mux := http.NewServeMux()
+	mux.Handle("GET "+cfg.Path, metricsHandler)
+
+	httpServer := httpserver.New(
+		mux,
+		httpserver.WithPort(cfg.Port),
+	)
+
+	return &App{
+		log:        log,
+		port:       cfg.Port,
+		httpServer: httpServer,
+	}
+}
+
+// Start - starts the metrics exposition application.
+func (a *App) Start() {
+	const op = "metricsapp.Start"
+
+	log := a.log.With(
+		slog.String("op", op),
+		slog.String("port", a.port),
+	)
+	log.Info("running metrics server")
+
+	a.httpServer.Start()
+}
+
+// Stop - stops the metrics exposition application.
+func (a *App) Stop() {
+	const op = "metricsapp.Stop"
+
+	log := a.log.With(
+		slog.String("op", op),
+		slog.String("port", a.port),
+	)
+	log.Info("stopping metrics server")
+
+	if err := a.httpServer.Stop(); err != nil {
+		log.Error("error stopping metrics server", sl.Err(err))
+	}
+}
+
+// ForceStop - stops the metrics exposition application immediately, closing active calls; a Stop in progress returns.
+func (a *App) ForceStop() {
+	const op = "metricsapp.ForceStop"
+
+	log := a.log.With(
+		slog.String("op", op),
+		slog.String("port", a.port),
+	)
+	log.Warn("stopping metrics server forcibly")
+
+	if err := a.httpServer.ForceStop(); err != nil {
+		log.Error("error stopping metrics server forcibly", sl.Err(err))
+	}
+}
+
+// Notify - notifies about metrics exposition application errors.
+func (a *App) Notify() <-chan error {
+	return a.httpServer.Notify()
+}
@@ -0,0 +1,98 @@
+package app
+
+import (
+	"fmt"
+	"log/slog"
+	"love-signal-users/internal/config"
+	"love-signal-users/pkg/featureflag"
+	"love-signal-users/pkg/logger/sl"
+	"slices"
+	"strings"
+)
+
+// logSinksPath is the config path of the log sinks.
+const logSinksPath = "log.sinks"
+
+// ConfigLoader loads the config from the same sources as on start.
+type ConfigLoader func() (*config.Config, error)
+
+// Reload re-reads the config and applies the parts that can change at runtime: log levels, call timeouts,
+// rate limits and feature flags. Invalid config is rejected as a whole. Changes that require a restart, e.g. ports or
+// the storage path, are not applied and are logged as warnings until the application is restarted.
+// It returns the paths of the changes applied since the previous reload and of the pending restart-only changes.
+func (a *App) Reload() (applied []string, restartRequired []string, err error) {
+	const op = "app.Reload"
+
+	log := a.log.With(slog.String("op", op))
+
+	a.reloadMutex.Lock()
+	defer a.reloadMutex.Unlock()
+
+	cfg, err := a.loadConfig()
+	if err != nil {
+		log.Error("config reload rejected", sl.Err(err))
+
+		return nil, nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	applied, _ = config.Changes(a.cfg, cfg)
+	_, restartRequired = config.Changes(a.startCfg, cfg)
+
+	// Levels are applied first, as they are the only part that can fail. Log sinks and the environment
+	// can only change on restart, so levels are applied if the number of sinks is the same; otherwise
+	// the levels of the sinks are not applied and wait for the restart with the sinks.
+	if len(cfg.Log.Sinks) == len(a.startCfg.Log.Sinks) {
+		if err = a.logLevels.Set(a.startCfg.Env, sinkLevels(cfg.Log.Sinks)); err != nil {
+			log.Error("config reload rejected", sl.Err(err))
+
+			return nil, nil, fmt.Errorf("%s: %w", op, err)
+		}
+	} else {
+		applied = slices.DeleteFunc(applied, func(path string) bool {
+			return strings.HasPrefix(path, logSinksPath)
+		})
+		if !slices.Contains(restartRequired, logSinksPath) {
+			restartRequired = append(restartRequired, logSinksPath)
+		}
+		log.Warn("log sink levels are not applied, as the number of log sinks has changed",
+			slog.Int("sinks on start", len(a.startCfg.Log.Sinks)),
+			slog.Int("sinks", len(cfg.Log.Sinks)),
+		)
+	}
+
+	a.grpcApp.Reload(cfg.GRPC)
+	a.flags.Set(featureFlags(cfg.FeatureFlags))
+
+	a.cfg = cfg
+
+	for _, path := range restartRequired {
+		log.Warn("config change requires restart", slog.String("path", path))
+	}
+	log.Info("config reloaded", slog.Any("applied", applied))
+
+	return applied, restartRequired, nil
+}
+
+// sinkLevels returns the levels of the log sinks.
+func sinkLevels(sinks []config.LogSinkConfig) []string {
+	levels := make([]string, 0, len(sinks))
+	for _, sink := range sinks {
+		levels = append(levels, sink.Level)
+	}
+
+	return levels
+}
+
+// featureFlags returns the feature flags of the config by name.
+func featureFlags(cfg map[string]config.FeatureFlagConfig) map[string]featureflag.Flag {
+	flags := make(map[string]featureflag.Flag, len(cfg))
+	for name, flag := range cfg {
+		flags[name] = featureflag.Flag{
+			Enabled:    flag.Enabled,
+			Percentage: flag.Percentage,
+			AllowList:  flag.AllowList,
+		}
+	}
+
+	return flags
+}
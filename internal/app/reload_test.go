@@ -0,0 +1,109 @@
+package app
+
+import (
+	"log/slog"
+	grpcapp "love-signal-users/internal/app/grpc"
+	"love-signal-users/internal/config"
+	"love-signal-users/pkg/featureflag"
+	"love-signal-users/pkg/logger"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func Test_ReloadRejectsInvalidConfig(t *testing.T) {
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "config.yaml")
+	err := os.WriteFile(configPath, []byte(`
+storage_path: `+filepath.Join(dir, "users.db")+`
+grpc:
+  port: "6005"
+  timeout: -1s
+`), 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := &config.Config{GRPC: config.GRPCConfig{Port: "6005", Timeout: time.Second}}
+	a := &App{
+		log: slog.New(slog.DiscardHandler),
+		loadConfig: func() (*config.Config, error) {
+			return config.Load(config.Flags{ConfigPath: configPath})
+		},
+		startCfg: cfg,
+		cfg:      cfg,
+	}
+
+	applied, restartRequired, err := a.Reload()
+	if err == nil {
+		t.Fatal("expected invalid config to be rejected")
+	}
+	if len(applied) != 0 || len(restartRequired) != 0 {
+		t.Errorf("expected no changes of rejected config, got: %v, %v", applied, restartRequired)
+	}
+	if a.cfg != cfg {
+		t.Error("expected applied config to be kept")
+	}
+}
+
+func Test_ReloadReplacesFeatureFlags(t *testing.T) {
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "config.yaml")
+	writeConfig := func(enabled string) {
+		t.Helper()
+
+		err := os.WriteFile(configPath, []byte(`
+env: prod
+storage_path: `+filepath.Join(dir, "users.db")+`
+grpc:
+  port: "6005"
+  timeout: 5s
+feature_flags:
+  feature:
+    enabled: `+enabled+`
+`), 0o600)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	loadConfig := func() (*config.Config, error) {
+		return config.Load(config.Flags{ConfigPath: configPath})
+	}
+
+	writeConfig("false")
+	cfg, err := loadConfig()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	log := slog.New(slog.DiscardHandler)
+	logLevels := logger.NewLevels()
+	logger.SetupLogger(cfg.Env, logger.WithLevels(logLevels))
+	a := &App{
+		log:        log,
+		grpcApp:    grpcapp.New(log, cfg.GRPC, nil, nil, nil, nil, nil, nil, 0, nil, nil, nil, nil),
+		loadConfig: loadConfig,
+		startCfg:   cfg,
+		cfg:        cfg,
+		logLevels:  logLevels,
+		flags:      featureflag.NewRegistry(featureFlags(cfg.FeatureFlags)),
+	}
+	flags := a.flags.Current()
+
+	writeConfig("true")
+	applied, _, err := a.Reload()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(applied) != 1 || applied[0] != "feature_flags" {
+		t.Errorf("expected feature flag change applied, got: %v", applied)
+	}
+	if !a.flags.Current().Enabled("feature", featureflag.User{}) {
+		t.Error("expected flag on after reload")
+	}
+	if flags.Enabled("feature", featureflag.User{}) {
+		t.Error("expected flags taken before reload to be kept")
+	}
+}
@@ -1,62 +1,196 @@
 package config
 
-import (
-	"flag"
-	"os"
-	"time"
-
-	"github.com/ilyakaznacheev/cleanenv"
-)
+import "time"
 
 // Config is the project configuration.
+// Fields tagged with reload:"true", and the fields nested in them, can be changed at runtime, see Changes.
 type Config struct {
-	Env         string     `yaml:"env" env-default:"local"`
-	StoragePath string     `yaml:"storage_path" env-required:"true"`
-	GRPC        GRPCConfig `yaml:"grpc" env-required:"true"`
+	Env          string                       `yaml:"env" env-default:"local"`
+	StoragePath  string                       `yaml:"storage_path" env-required:"true"`
+	GRPC         GRPCConfig                   `yaml:"grpc" env-required:"true"`
+	HTTP         HTTPConfig                   `yaml:"http"`
+	Metrics      MetricsConfig                `yaml:"metrics"`
+	FollowEvents FollowEventsConfig           `yaml:"follow_events"`
+	Tracing      TracingConfig                `yaml:"tracing"`
+	Log          LogConfig                    `yaml:"log"`
+	Admin        AdminConfig                  `yaml:"admin"`
+	SlowQuery    SlowQueryConfig              `yaml:"slow_query"`
+	Audit        AuditConfig                  `yaml:"audit"`
+	Shutdown     ShutdownConfig               `yaml:"shutdown"`
+	FeatureFlags map[string]FeatureFlagConfig `yaml:"feature_flags" reload:"true"`
 }
 
 // GRPCConfig is the gRPC server configuration.
+// Timeout is the deadline of unary calls; methods with own timeout use it instead.
+// AdminCallers are the callers allowed to call the admin service: identities of client certificates or peer addresses.
+// FollowEventsCallers are the callers allowed to subscribe to follow events of users, e.g. the API gateway,
+// matched the same way; the events of any user can be streamed, so end-user clients must not be among them.
 type GRPCConfig struct {
-	Port    string        `yaml:"port" env-required:"true"`
-	Timeout time.Duration `yaml:"timeout" env-required:"true"`
+	Host                  string                   `yaml:"host"`
+	Port                  string                   `yaml:"port" env-required:"true"`
+	UnixSocket            string                   `yaml:"unix_socket"`
+	Timeout               time.Duration            `yaml:"timeout" env-required:"true" reload:"true"`
+	MethodTimeouts        map[string]time.Duration `yaml:"method_timeouts" reload:"true"`
+	MaxRecvMsgSize        int                      `yaml:"max_recv_msg_size" env-default:"4194304"`
+	MaxSendMsgSize        int                      `yaml:"max_send_msg_size" env-default:"4194304"`
+	MaxConcurrentStreams  uint32                   `yaml:"max_concurrent_streams"`
+	ConnectionIdleTimeout time.Duration            `yaml:"connection_idle_timeout"`
+	Gzip                  bool                     `yaml:"gzip" env-default:"false"`
+	DrainTimeout          time.Duration            `yaml:"drain_timeout" env-default:"10s"`
+	Keepalive             KeepaliveConfig          `yaml:"keepalive"`
+	RateLimit             RateLimitConfig          `yaml:"rate_limit"`
+	TLS                   TLSConfig                `yaml:"tls"`
+	AdminCallers          []string                 `yaml:"admin_callers"`
+	FollowEventsCallers   []string                 `yaml:"follow_events_callers"`
 }
 
-// MustRun loads config and panics if any error occurs.
-func MustLoad() *Config {
-	path := fetchConfigPath()
-	if path == "" {
-		panic("config path is empty")
-	}
+// KeepaliveConfig is the gRPC server keepalive configuration.
+// Zero values keep the gRPC defaults.
+type KeepaliveConfig struct {
+	Time                  time.Duration `yaml:"time"`
+	Timeout               time.Duration `yaml:"timeout"`
+	MaxConnectionAge      time.Duration `yaml:"max_connection_age"`
+	MaxConnectionAgeGrace time.Duration `yaml:"max_connection_age_grace"`
+	MinTime               time.Duration `yaml:"min_time" env-default:"5m"`
+	PermitWithoutStream   bool          `yaml:"permit_without_stream" env-default:"false"`
+}
 
-	return MustLoadByPath(path)
+// TLSConfig is the gRPC server TLS configuration.
+// Mutual TLS is enabled when the client CA file is set.
+type TLSConfig struct {
+	Enabled      bool   `yaml:"enabled" env-default:"false"`
+	CertFile     string `yaml:"cert_file"`
+	KeyFile      string `yaml:"key_file"`
+	ClientCAFile string `yaml:"client_ca_file"`
+	MinVersion   string `yaml:"min_version" env-default:"1.2"`
 }
 
-// MustRun loads config by path and panics if any error occurs.
-func MustLoadByPath(configPath string) *Config {
-	if _, err := os.Stat(configPath); os.IsNotExist(err) {
-		panic("config file does not exist: " + configPath)
-	}
+// RateLimitConfig is the gRPC server rate limiting configuration.
+// Limits are applied per caller; methods without own limit use the default one.
+type RateLimitConfig struct {
+	Enabled bool                   `yaml:"enabled" env-default:"false"`
+	Default LimitConfig            `yaml:"default" reload:"true"`
+	Methods map[string]LimitConfig `yaml:"methods" reload:"true"`
+}
 
-	var cfg Config
-	if err := cleanenv.ReadConfig(configPath, &cfg); err != nil {
-		panic("cannot read config: " + err.Error())
-	}
+// LimitConfig is the token bucket limit configuration.
+// A limit with zero RPS does not restrict calls; negative RPS and burst are invalid.
+type LimitConfig struct {
+	RPS   float64 `yaml:"rps"`
+	Burst int     `yaml:"burst"`
+}
 
-	return &cfg
+// HTTPConfig is the HTTP server configuration.
+type HTTPConfig struct {
+	Enabled         bool          `yaml:"enabled" env-default:"false"`
+	Port            string        `yaml:"port" env-default:"8080"`
+	ReadTimeout     time.Duration `yaml:"read_timeout" env-default:"5s"`
+	WriteTimeout    time.Duration `yaml:"write_timeout" env-default:"5s"`
+	ShutdownTimeout time.Duration `yaml:"shutdown_timeout" env-default:"5s"`
 }
 
-// fetchConfigPath fetches config path from command line flag or environment variable.
-// Priority: flag > env > default.
-// Default value is empty string.
-func fetchConfigPath() string {
-	var path string
+// MetricsConfig is the Prometheus metrics exposition configuration.
+type MetricsConfig struct {
+	Enabled bool   `yaml:"enabled" env-default:"false"`
+	Port    string `yaml:"port" env-default:"9105"`
+	Path    string `yaml:"path" env-default:"/metrics"`
+}
 
-	flag.StringVar(&path, "config", "", "path to config file")
-	flag.Parse()
+// FollowEventsConfig is the follow events streaming configuration.
+type FollowEventsConfig struct {
+	BufferSize        int           `yaml:"buffer_size" env-default:"64"`
+	KeepaliveInterval time.Duration `yaml:"keepalive_interval" env-default:"30s"`
+}
 
-	if path == "" {
-		path = os.Getenv("CONFIG_PATH")
-	}
+// AdminConfig is the admin server configuration: pprof, liveness and readiness probes and build info.
+type AdminConfig struct {
+	Enabled         bool          `yaml:"enabled" env-default:"true"`
+	Host            string        `yaml:"host" env-default:"127.0.0.1"`
+	Port            string        `yaml:"port" env-default:"9106"`
+	MigrationsTable string        `yaml:"migrations_table" env-default:"migrations"`
+	ReadyTimeout    time.Duration `yaml:"ready_timeout" env-default:"1s"`
+}
+
+// SlowQueryConfig is the configuration of logging storage statements that run longer than the threshold.
+type SlowQueryConfig struct {
+	Enabled   bool          `yaml:"enabled" env-default:"false"`
+	Threshold time.Duration `yaml:"threshold" env-default:"100ms"`
+	Explain   bool          `yaml:"explain" env-default:"false"`
+}
+
+// AuditConfig is the configuration of the audit log of state-changing calls.
+type AuditConfig struct {
+	Enabled bool   `yaml:"enabled" env-default:"false"`
+	Path    string `yaml:"path" env-default:"./storage/audit.jsonl"`
+}
+
+// FeatureFlagConfig is the configuration of a feature flag.
+// The flag is on for all users if it is enabled, otherwise for the percentage of users, from 0 to 100,
+// chosen by user ID, and for the users with the external IDs of the allow-list.
+type FeatureFlagConfig struct {
+	Enabled    bool    `yaml:"enabled"`
+	Percentage float64 `yaml:"percentage"`
+	AllowList  []int64 `yaml:"allow_list"`
+}
+
+// ShutdownConfig is the application shutdown configuration.
+// On shutdown, the readiness probe fails first; after the readiness delay, the components are stopped
+// in reverse order of start. The timeout bounds the whole shutdown, including the delay; servers still
+// stopping after it are stopped forcibly.
+type ShutdownConfig struct {
+	Timeout        time.Duration `yaml:"timeout" env-default:"30s"`
+	ReadinessDelay time.Duration `yaml:"readiness_delay"`
+}
+
+// LogConfig is the logging configuration.
+// Without sinks, logs are written to stdout in the format and level of the environment preset.
+type LogConfig struct {
+	Sinks     []LogSinkConfig    `yaml:"sinks"`
+	Redaction LogRedactionConfig `yaml:"redaction"`
+	Sampling  LogSamplingConfig  `yaml:"sampling"`
+}
+
+// LogSamplingConfig is the configuration of sampling repeated log records.
+// Records with the same message and operation over the limit of their level are collapsed
+// into one line with the number of suppressed records per window. Levels without limits are not sampled.
+type LogSamplingConfig struct {
+	Enabled bool                              `yaml:"enabled" env-default:"false"`
+	Window  time.Duration                     `yaml:"window" env-default:"10s"`
+	Levels  map[string]LogSamplingLimitConfig `yaml:"levels"`
+}
+
+// LogSamplingLimitConfig is the limit of repeated records of a level: the burst and the rate per second after it.
+type LogSamplingLimitConfig struct {
+	Burst int     `yaml:"burst"`
+	Rate  float64 `yaml:"rate"`
+}
+
+// LogRedactionConfig is the configuration of PII redaction in logs.
+// Struct fields tagged as sensitive are always masked.
+type LogRedactionConfig struct {
+	MaskedKeys []string `yaml:"masked_keys"`
+	HashedKeys []string `yaml:"hashed_keys"`
+	HashSalt   string   `yaml:"hash_salt" env:"LOG_HASH_SALT" log:"sensitive"`
+}
+
+// LogSinkConfig is the configuration of a log destination.
+type LogSinkConfig struct {
+	Type       string `yaml:"type"`
+	Level      string `yaml:"level" reload:"true"`
+	Path       string `yaml:"path"`
+	MaxSizeMB  int    `yaml:"max_size_mb"`
+	MaxAgeDays int    `yaml:"max_age_days"`
+	MaxBackups int    `yaml:"max_backups"`
+	Compress   bool   `yaml:"compress"`
+}
 
-	return path
+// TracingConfig is the OpenTelemetry tracing configuration.
+// Spans are exported to the OTLP endpoint if it is set, otherwise to the file or stdout.
+type TracingConfig struct {
+	Enabled      bool    `yaml:"enabled" env-default:"false"`
+	ServiceName  string  `yaml:"service_name" env-default:"love-signal-users"`
+	OTLPEndpoint string  `yaml:"otlp_endpoint"`
+	OTLPInsecure bool    `yaml:"otlp_insecure" env-default:"false"`
+	File         string  `yaml:"file"`
+	SampleRatio  float64 `yaml:"sample_ratio" env-default:"1"`
 }
@@ -0,0 +1,168 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"love-signal-users/pkg/logger/redact"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// writeConfig writes the config file to the directory and returns its path.
+func writeConfig(t *testing.T, dir, name, data string) string {
+	t.Helper()
+
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	return path
+}
+
+func Test_LoadAppliesSourcesInOrder(t *testing.T) {
+	dir := t.TempDir()
+	configPath := writeConfig(t, dir, "config.yaml", `
+env: dev
+storage_path: `+filepath.Join(dir, "users.db")+`
+grpc:
+  port: "6000"
+  timeout: 5s
+http:
+  port: "8081"
+metrics:
+  port: "9001"
+`)
+	writeConfig(t, dir, "config.dev.yaml", `
+grpc:
+  port: "6001"
+http:
+  port: "8082"
+metrics:
+  port: "9002"
+`)
+	t.Setenv("USERS_HTTP_PORT", "8083")
+	t.Setenv("USERS_METRICS_PORT", "9003")
+
+	cfg, err := Load(Flags{ConfigPath: configPath, Overrides: []string{"metrics.port=9004"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if cfg.HTTP.ReadTimeout != 5*time.Second {
+		t.Errorf("expected default http.read_timeout 5s, got: %s", cfg.HTTP.ReadTimeout)
+	}
+	if cfg.GRPC.Timeout != 5*time.Second {
+		t.Errorf("expected grpc.timeout from config file, got: %s", cfg.GRPC.Timeout)
+	}
+	if cfg.GRPC.Port != "6001" {
+		t.Errorf("expected grpc.port from env config file, got: %s", cfg.GRPC.Port)
+	}
+	if cfg.HTTP.Port != "8083" {
+		t.Errorf("expected http.port from environment variable, got: %s", cfg.HTTP.Port)
+	}
+	if cfg.Metrics.Port != "9004" {
+		t.Errorf("expected metrics.port from override, got: %s", cfg.Metrics.Port)
+	}
+}
+
+func Test_LoadRejectsUnknownKeys(t *testing.T) {
+	dir := t.TempDir()
+	configPath := writeConfig(t, dir, "config.yaml", `
+storage_path: `+filepath.Join(dir, "users.db")+`
+grpc:
+  port: "6000"
+  timeout: 5s
+  drain_timeuot: 1s
+`)
+
+	if _, err := Load(Flags{ConfigPath: configPath}); err == nil || !strings.Contains(err.Error(), "drain_timeuot") {
+		t.Errorf("expected error of the unknown key, got: %v", err)
+	}
+}
+
+func Test_ValidateReturnsAllErrors(t *testing.T) {
+	cfg := &Config{
+		Env:         "staging",
+		StoragePath: filepath.Join(t.TempDir(), "users.db"),
+		GRPC:        GRPCConfig{Port: "0", Timeout: time.Second, MaxRecvMsgSize: 1, MaxSendMsgSize: 1},
+		FollowEvents: FollowEventsConfig{
+			BufferSize:        1,
+			KeepaliveInterval: time.Second,
+		},
+		Tracing:      TracingConfig{Enabled: true, SampleRatio: 2},
+		Shutdown:     ShutdownConfig{Timeout: time.Second},
+		FeatureFlags: map[string]FeatureFlagConfig{"feature": {Percentage: math.NaN()}},
+	}
+
+	err := cfg.Validate()
+	if err == nil {
+		t.Fatal("expected validation errors")
+	}
+
+	for _, path := range []string{"env", "grpc.port", "tracing.sample_ratio", "feature_flags.feature.percentage"} {
+		if !strings.Contains(err.Error(), path+":") {
+			t.Errorf("expected error of %s, got: %v", path, err)
+		}
+	}
+
+	var joined interface{ Unwrap() []error }
+	if !errors.As(err, &joined) || len(joined.Unwrap()) != 4 {
+		t.Errorf("expected 4 errors joined, got: %v", err)
+	}
+}
+
+func Test_PrintMasksSecrets(t *testing.T) {
+	cfg := &Config{Log: LogConfig{Redaction: LogRedactionConfig{HashSalt: "pepper"}}}
+
+	var out bytes.Buffer
+	if err := Print(&out, cfg); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if strings.Contains(out.String(), "pepper") || !strings.Contains(out.String(), redact.Mask) {
+		t.Errorf("expected hash salt masked, got: %s", out.String())
+	}
+	if cfg.Log.Redaction.HashSalt != "pepper" {
+		t.Errorf("expected config not changed, got hash salt: %s", cfg.Log.Redaction.HashSalt)
+	}
+}
+
+func Test_ValidateRateLimits(t *testing.T) {
+	tests := []struct {
+		name     string
+		limit    LimitConfig
+		expected string
+	}{
+		{name: "no limit", limit: LimitConfig{}},
+		{name: "limit", limit: LimitConfig{RPS: 0.5, Burst: 1}},
+		{name: "negative rps", limit: LimitConfig{RPS: -1, Burst: 1}, expected: "grpc.rate_limit.default.rps:"},
+		{name: "rps not a number", limit: LimitConfig{RPS: math.NaN(), Burst: 1}, expected: "grpc.rate_limit.default.rps:"},
+		{name: "infinite rps", limit: LimitConfig{RPS: math.Inf(1), Burst: 1}, expected: "grpc.rate_limit.default.rps:"},
+		{name: "negative burst", limit: LimitConfig{Burst: -1}, expected: "grpc.rate_limit.default.burst:"},
+		{name: "rps without burst", limit: LimitConfig{RPS: 1}, expected: "grpc.rate_limit.default.burst:"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := &validator{}
+			v.limit("grpc.rate_limit.default", tt.limit)
+			err := errors.Join(v.errs...)
+
+			if tt.expected == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.expected) {
+				t.Errorf("expected error of %s, got: %v", tt.expected, err)
+			}
+		})
+	}
+}
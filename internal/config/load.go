@@ -0,0 +1,342 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"gopkg.in/yaml.v3"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// EnvPrefix is the prefix of environment variables that override config values.
+// The name of the variable is the prefix and the path of the value in upper case with underscores,
+// e.g. USERS_GRPC_PORT overrides grpc.port.
+const EnvPrefix = "USERS_"
+
+var (
+	// ErrNoConfigPath is returned when the path to the config file is not set.
+	ErrNoConfigPath = errors.New("config path is empty")
+	// ErrUnknownKey is returned when an override sets a value that is not in the config.
+	ErrUnknownKey = errors.New("unknown config key")
+)
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// Flags are the command line flags of the config.
+type Flags struct {
+	// ConfigPath is the path to the base config file.
+	ConfigPath string
+	// EnvConfigPath is the path to the environment-specific config file.
+	// By default, it is the base config file with the environment before the extension, e.g. config.prod.yaml,
+	// and it is skipped if it does not exist.
+	EnvConfigPath string
+	// Overrides are the values set by their paths, e.g. grpc.port=5000.
+	Overrides []string
+	// PrintConfig requests printing the effective config.
+	PrintConfig bool
+	// ValidateConfig requests validating the config without starting the application.
+	ValidateConfig bool
+}
+
+// overrides is the repeated flag of config values.
+type overrides []string
+
+func (o *overrides) String() string {
+	return strings.Join(*o, ",")
+}
+
+func (o *overrides) Set(value string) error {
+	*o = append(*o, value)
+	return nil
+}
+
+// ParseFlags parses the command line flags of the config.
+// The config paths fall back to CONFIG_PATH and CONFIG_ENV_PATH environment variables.
+func ParseFlags() Flags {
+	var flags Flags
+	var values overrides
+
+	flag.StringVar(&flags.ConfigPath, "config", "", "path to config file")
+	flag.StringVar(&flags.EnvConfigPath, "env-config", "", "path to environment-specific config file, <config>.<env>.yaml by default")
+	flag.Var(&values, "set", "override config value by its path, e.g. --set grpc.port=5000; may be repeated")
+	flag.BoolVar(&flags.PrintConfig, "print-config", false, "print effective config with secrets masked and exit")
+	flag.BoolVar(&flags.ValidateConfig, "validate-config", false, "validate config and exit")
+	flag.Parse()
+
+	flags.Overrides = values
+
+	if flags.ConfigPath == "" {
+		flags.ConfigPath = os.Getenv("CONFIG_PATH")
+	}
+	if flags.EnvConfigPath == "" {
+		flags.EnvConfigPath = os.Getenv("CONFIG_ENV_PATH")
+	}
+
+	return flags
+}
+
+// Load loads the config from the layers, each overriding the previous ones:
+// defaults, the base config file, the environment-specific config file, environment variables and overrides.
+// The loaded config is validated, all errors are returned together.
+func Load(flags Flags) (*Config, error) {
+	if flags.ConfigPath == "" {
+		return nil, ErrNoConfigPath
+	}
+
+	var cfg Config
+	root := reflect.ValueOf(&cfg).Elem()
+
+	if err := setDefaults(root); err != nil {
+		return nil, err
+	}
+
+	if err := readFile(flags.ConfigPath, &cfg); err != nil {
+		return nil, err
+	}
+
+	envConfigPath := flags.EnvConfigPath
+	if envConfigPath == "" {
+		envConfigPath = defaultEnvConfigPath(flags.ConfigPath, envName(cfg.Env))
+	}
+	if err := readFile(envConfigPath, &cfg); err != nil {
+		if flags.EnvConfigPath != "" || !errors.Is(err, os.ErrNotExist) {
+			return nil, err
+		}
+	}
+
+	if err := applyEnv(root); err != nil {
+		return nil, err
+	}
+
+	if err := applyOverrides(root, flags.Overrides); err != nil {
+		return nil, err
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return &cfg, err
+	}
+
+	return &cfg, nil
+}
+
+// MustLoad loads the config by the command line flags and panics if any error occurs.
+func MustLoad() *Config {
+	cfg, err := Load(ParseFlags())
+	if err != nil {
+		panic("cannot load config: " + err.Error())
+	}
+
+	return cfg
+}
+
+// MustLoadByPath loads config by path and panics if any error occurs.
+func MustLoadByPath(configPath string) *Config {
+	cfg, err := Load(Flags{ConfigPath: configPath})
+	if err != nil {
+		panic("cannot load config: " + err.Error())
+	}
+
+	return cfg
+}
+
+// envName returns the environment, which can be overridden by the environment variable before the env config file is read.
+func envName(env string) string {
+	if value, ok := os.LookupEnv(EnvPrefix + "ENV"); ok {
+		return value
+	}
+
+	return env
+}
+
+// defaultEnvConfigPath returns the path to the environment-specific config file next to the base config file.
+func defaultEnvConfigPath(configPath, env string) string {
+	ext := filepath.Ext(configPath)
+
+	return strings.TrimSuffix(configPath, ext) + "." + env + ext
+}
+
+// readFile reads the config file over the config. Values missing in the file are kept.
+// Keys that are not in the config are errors, so that misspelled keys are not silently ignored.
+func readFile(path string, cfg *Config) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("cannot read config file: %w", err)
+	}
+
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err = decoder.Decode(cfg); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("cannot parse config file %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// setDefaults sets the values of the env-default tags.
+func setDefaults(root reflect.Value) error {
+	return walk(root, "", func(field reflect.StructField, value reflect.Value, path string) error {
+		def, ok := field.Tag.Lookup("env-default")
+		if !ok {
+			return nil
+		}
+
+		if err := setValue(value, def); err != nil {
+			return fmt.Errorf("default of %s: %w", path, err)
+		}
+
+		return nil
+	})
+}
+
+// applyEnv sets the values of the environment variables: the ones of the env tags, then the prefixed ones.
+func applyEnv(root reflect.Value) error {
+	return walk(root, "", func(field reflect.StructField, value reflect.Value, path string) error {
+		names := make([]string, 0, 2)
+		if env := field.Tag.Get("env"); env != "" {
+			names = append(names, strings.Split(env, ",")...)
+		}
+		names = append(names, envVarName(path))
+
+		for _, name := range names {
+			raw, ok := os.LookupEnv(name)
+			if !ok {
+				continue
+			}
+
+			if err := setValue(value, raw); err != nil {
+				return fmt.Errorf("environment variable %s: %w", name, err)
+			}
+
+			return nil
+		}
+
+		return nil
+	})
+}
+
+// applyOverrides sets the values of the overrides in the path=value format.
+func applyOverrides(root reflect.Value, values []string) error {
+	for _, override := range values {
+		path, raw, ok := strings.Cut(override, "=")
+		if !ok {
+			return fmt.Errorf("override %q: expected path=value", override)
+		}
+
+		found := false
+		err := walk(root, "", func(_ reflect.StructField, value reflect.Value, fieldPath string) error {
+			if fieldPath != path {
+				return nil
+			}
+			found = true
+
+			return setValue(value, raw)
+		})
+		if err != nil {
+			return fmt.Errorf("override %s: %w", path, err)
+		}
+		if !found {
+			return fmt.Errorf("override %s: %w", path, ErrUnknownKey)
+		}
+	}
+
+	return nil
+}
+
+// envVarName returns the name of the prefixed environment variable of the config path.
+func envVarName(path string) string {
+	return EnvPrefix + strings.ToUpper(strings.ReplaceAll(path, ".", "_"))
+}
+
+// walk calls the function for each field of the config that is not a nested struct, with its yaml path.
+func walk(v reflect.Value, prefix string, fn func(field reflect.StructField, value reflect.Value, path string) error) error {
+	for i := range v.NumField() {
+		field := v.Type().Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("yaml"), ",")
+		if name == "" || name == "-" {
+			continue
+		}
+
+		path := name
+		if prefix != "" {
+			path = prefix + "." + name
+		}
+
+		value := v.Field(i)
+		if value.Kind() == reflect.Struct {
+			if err := walk(value, path, fn); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if err := fn(field, value, path); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// setValue parses the raw value into the field. Lists are comma-separated.
+func setValue(value reflect.Value, raw string) error {
+	if value.Type() == durationType {
+		d, err := time.ParseDuration(raw)
+		if err != nil {
+			return err
+		}
+		value.SetInt(int64(d))
+
+		return nil
+	}
+
+	switch value.Kind() {
+	case reflect.String:
+		value.SetString(raw)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return err
+		}
+		value.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(raw, 10, value.Type().Bits())
+		if err != nil {
+			return err
+		}
+		value.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(raw, 10, value.Type().Bits())
+		if err != nil {
+			return err
+		}
+		value.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		n, err := strconv.ParseFloat(raw, value.Type().Bits())
+		if err != nil {
+			return err
+		}
+		value.SetFloat(n)
+	case reflect.Slice:
+		if value.Type().Elem().Kind() != reflect.String {
+			return fmt.Errorf("unsupported type %s", value.Type())
+		}
+		items := make([]string, 0)
+		for _, item := range strings.Split(raw, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+		value.Set(reflect.ValueOf(items))
+	default:
+		return fmt.Errorf("unsupported type %s", value.Type())
+	}
+
+	return nil
+}
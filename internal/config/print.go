@@ -0,0 +1,74 @@
+package config
+
+import (
+	"fmt"
+	"gopkg.in/yaml.v3"
+	"io"
+	"love-signal-users/pkg/logger/redact"
+	"reflect"
+)
+
+// Print writes the config as YAML with the values of the fields tagged as sensitive masked.
+func Print(w io.Writer, cfg *Config) error {
+	masked := reflect.New(reflect.TypeOf(*cfg)).Elem()
+	masked.Set(maskSensitive(reflect.ValueOf(*cfg)))
+
+	data, err := yaml.Marshal(masked.Interface())
+	if err != nil {
+		return fmt.Errorf("cannot marshal config: %w", err)
+	}
+
+	_, err = w.Write(data)
+
+	return err
+}
+
+// maskSensitive returns a copy of the value with non-empty sensitive string fields masked.
+// Slices and maps are copied, so the original value is not changed.
+func maskSensitive(v reflect.Value) reflect.Value {
+	switch v.Kind() {
+	case reflect.Struct:
+		out := reflect.New(v.Type()).Elem()
+		out.Set(v)
+		for i := range v.NumField() {
+			field := v.Type().Field(i)
+			if !field.IsExported() {
+				continue
+			}
+
+			if field.Tag.Get(redact.Tag) == redact.Sensitive && field.Type.Kind() == reflect.String && !v.Field(i).IsZero() {
+				out.Field(i).SetString(redact.Mask)
+				continue
+			}
+
+			out.Field(i).Set(maskSensitive(v.Field(i)))
+		}
+
+		return out
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+
+		out := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := range v.Len() {
+			out.Index(i).Set(maskSensitive(v.Index(i)))
+		}
+
+		return out
+	case reflect.Map:
+		if v.IsNil() {
+			return v
+		}
+
+		out := reflect.MakeMapWithSize(v.Type(), v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			out.SetMapIndex(iter.Key(), maskSensitive(iter.Value()))
+		}
+
+		return out
+	default:
+		return v
+	}
+}
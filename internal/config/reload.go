@@ -0,0 +1,53 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Changes returns the paths of the values that differ between the configs, split into the ones that can be
+// applied at runtime and the ones that require a restart. Elements of lists of sections are compared one by one
+// if the lists have the same length, e.g. log.sinks[0].level; otherwise the whole list is changed.
+func Changes(old, new *Config) (reloadable, restartRequired []string) {
+	c := &changes{}
+	c.diff(reflect.ValueOf(old).Elem(), reflect.ValueOf(new).Elem(), "", false)
+
+	return c.reloadable, c.restartRequired
+}
+
+type changes struct {
+	reloadable      []string
+	restartRequired []string
+}
+
+// diff compares the values and records the paths of the changed ones.
+// Reload is whether the value or any section it is nested in is tagged as reloadable.
+func (c *changes) diff(old, new reflect.Value, path string, reload bool) {
+	switch {
+	case old.Kind() == reflect.Struct:
+		for i := range old.NumField() {
+			field := old.Type().Field(i)
+			name, _, _ := strings.Cut(field.Tag.Get("yaml"), ",")
+			if name == "" || name == "-" {
+				continue
+			}
+
+			fieldPath := name
+			if path != "" {
+				fieldPath = path + "." + name
+			}
+
+			c.diff(old.Field(i), new.Field(i), fieldPath, reload || field.Tag.Get("reload") == "true")
+		}
+	case old.Kind() == reflect.Slice && old.Type().Elem().Kind() == reflect.Struct && old.Len() == new.Len():
+		for i := range old.Len() {
+			c.diff(old.Index(i), new.Index(i), fmt.Sprintf("%s[%d]", path, i), reload)
+		}
+	case reflect.DeepEqual(old.Interface(), new.Interface()):
+	case reload:
+		c.reloadable = append(c.reloadable, path)
+	default:
+		c.restartRequired = append(c.restartRequired, path)
+	}
+}
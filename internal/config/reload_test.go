@@ -0,0 +1,56 @@
+package config
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func Test_ChangesSplitsReloadableAndRestartRequired(t *testing.T) {
+	old := &Config{
+		StoragePath: "./storage/users.db",
+		GRPC:        GRPCConfig{Port: "6005", Timeout: time.Second},
+		Log:         LogConfig{Sinks: []LogSinkConfig{{Type: "stdout_json", Level: "info"}}},
+	}
+	new := &Config{
+		StoragePath: "./storage/other.db",
+		GRPC: GRPCConfig{
+			Port:           "6006",
+			Timeout:        2 * time.Second,
+			MethodTimeouts: map[string]time.Duration{"GetUserData": time.Second},
+		},
+		Log:          LogConfig{Sinks: []LogSinkConfig{{Type: "stdout_json", Level: "debug"}}},
+		FeatureFlags: map[string]FeatureFlagConfig{"feature": {Enabled: true}},
+	}
+
+	reloadable, restartRequired := Changes(old, new)
+
+	slices.Sort(reloadable)
+	slices.Sort(restartRequired)
+	expectedReloadable := []string{"feature_flags", "grpc.method_timeouts", "grpc.timeout", "log.sinks[0].level"}
+	if !slices.Equal(reloadable, expectedReloadable) {
+		t.Errorf("expected reloadable changes %v, got: %v", expectedReloadable, reloadable)
+	}
+	expectedRestartRequired := []string{"grpc.port", "storage_path"}
+	if !slices.Equal(restartRequired, expectedRestartRequired) {
+		t.Errorf("expected restart-required changes %v, got: %v", expectedRestartRequired, restartRequired)
+	}
+}
+
+func Test_ChangesOfSinksCountRequireRestart(t *testing.T) {
+	old := &Config{Log: LogConfig{Sinks: []LogSinkConfig{{Type: "stdout_json", Level: "info"}}}}
+	new := &Config{Log: LogConfig{Sinks: []LogSinkConfig{
+		{Type: "stdout_json", Level: "debug"},
+		{Type: "file", Path: "./logs/users.log"},
+	}}}
+
+	reloadable, restartRequired := Changes(old, new)
+	if len(reloadable) != 0 || !slices.Equal(restartRequired, []string{"log.sinks"}) {
+		t.Errorf("expected only log.sinks to require restart, got reloadable: %v, restart-required: %v",
+			reloadable, restartRequired)
+	}
+
+	if reloadable, restartRequired = Changes(old, old); len(reloadable) != 0 || len(restartRequired) != 0 {
+		t.Errorf("expected no changes of the same config, got: %v, %v", reloadable, restartRequired)
+	}
+}
@@ -0,0 +1,263 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"love-signal-users/pkg/grpcserver"
+	"love-signal-users/pkg/logger"
+	"math"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var envs = []string{"local", "dev", "prod"}
+
+// validator collects validation errors of the config values by their paths.
+type validator struct {
+	errs []error
+}
+
+func (v *validator) fail(path, format string, args ...any) {
+	v.errs = append(v.errs, fmt.Errorf("%s: %s", path, fmt.Sprintf(format, args...)))
+}
+
+// Validate checks the config values and returns all found errors together.
+func (c *Config) Validate() error {
+	v := &validator{}
+
+	v.required(reflect.ValueOf(c).Elem())
+
+	if c.Env != "" && !slices.Contains(envs, c.Env) {
+		v.fail("env", "must be one of %s", strings.Join(envs, ", "))
+	}
+	if c.StoragePath != "" {
+		v.writableDir("storage_path", filepath.Dir(c.StoragePath))
+	}
+
+	v.grpc(c.GRPC)
+
+	if c.HTTP.Enabled {
+		v.port("http.port", c.HTTP.Port)
+		v.positive("http.read_timeout", c.HTTP.ReadTimeout)
+		v.positive("http.write_timeout", c.HTTP.WriteTimeout)
+		v.positive("http.shutdown_timeout", c.HTTP.ShutdownTimeout)
+	}
+
+	if c.Metrics.Enabled {
+		v.port("metrics.port", c.Metrics.Port)
+		if !strings.HasPrefix(c.Metrics.Path, "/") {
+			v.fail("metrics.path", "must start with /")
+		}
+	}
+
+	if c.FollowEvents.BufferSize <= 0 {
+		v.fail("follow_events.buffer_size", "must be positive")
+	}
+	v.positive("follow_events.keepalive_interval", c.FollowEvents.KeepaliveInterval)
+
+	if c.Tracing.Enabled && (c.Tracing.SampleRatio < 0 || c.Tracing.SampleRatio > 1) {
+		v.fail("tracing.sample_ratio", "must be between 0 and 1")
+	}
+
+	v.log(c.Log)
+
+	if c.Admin.Enabled {
+		v.port("admin.port", c.Admin.Port)
+		v.positive("admin.ready_timeout", c.Admin.ReadyTimeout)
+		if c.Admin.MigrationsTable == "" {
+			v.fail("admin.migrations_table", "is required")
+		}
+	}
+
+	if c.SlowQuery.Enabled {
+		v.positive("slow_query.threshold", c.SlowQuery.Threshold)
+	}
+
+	if c.Audit.Enabled {
+		if c.Audit.Path == "" {
+			v.fail("audit.path", "is required")
+		} else {
+			v.writableDir("audit.path", filepath.Dir(c.Audit.Path))
+		}
+	}
+
+	for name, flag := range c.FeatureFlags {
+		v.featureFlag("feature_flags."+name, flag)
+	}
+
+	v.positive("shutdown.timeout", c.Shutdown.Timeout)
+	v.notNegative("shutdown.readiness_delay", c.Shutdown.ReadinessDelay)
+	if c.Shutdown.ReadinessDelay >= c.Shutdown.Timeout && c.Shutdown.Timeout > 0 {
+		v.fail("shutdown.readiness_delay", "must be less than shutdown.timeout")
+	}
+
+	return errors.Join(v.errs...)
+}
+
+func (v *validator) grpc(c GRPCConfig) {
+	if c.UnixSocket == "" {
+		v.port("grpc.port", c.Port)
+	} else {
+		v.writableDir("grpc.unix_socket", filepath.Dir(c.UnixSocket))
+	}
+
+	v.positive("grpc.timeout", c.Timeout)
+	for method, timeout := range c.MethodTimeouts {
+		v.positive("grpc.method_timeouts."+method, timeout)
+	}
+	if c.MaxRecvMsgSize <= 0 {
+		v.fail("grpc.max_recv_msg_size", "must be positive")
+	}
+	if c.MaxSendMsgSize <= 0 {
+		v.fail("grpc.max_send_msg_size", "must be positive")
+	}
+	v.notNegative("grpc.drain_timeout", c.DrainTimeout)
+	v.notNegative("grpc.connection_idle_timeout", c.ConnectionIdleTimeout)
+
+	if c.TLS.Enabled {
+		v.file("grpc.tls.cert_file", c.TLS.CertFile)
+		v.file("grpc.tls.key_file", c.TLS.KeyFile)
+		if c.TLS.ClientCAFile != "" {
+			v.file("grpc.tls.client_ca_file", c.TLS.ClientCAFile)
+		}
+		if _, err := grpcserver.ParseTLSVersion(c.TLS.MinVersion); err != nil {
+			v.fail("grpc.tls.min_version", "%v", err)
+		}
+	}
+
+	if c.RateLimit.Enabled {
+		v.limit("grpc.rate_limit.default", c.RateLimit.Default)
+		for method, limit := range c.RateLimit.Methods {
+			v.limit("grpc.rate_limit.methods."+method, limit)
+		}
+	}
+}
+
+func (v *validator) log(c LogConfig) {
+	for i, sink := range c.Sinks {
+		path := fmt.Sprintf("log.sinks[%d]", i)
+
+		switch sink.Type {
+		case logger.SinkStdoutPretty, logger.SinkStdoutJSON:
+		case logger.SinkFile:
+			if sink.Path == "" {
+				v.fail(path+".path", "is required for file sink")
+			} else {
+				v.writableDir(path+".path", filepath.Dir(sink.Path))
+			}
+		default:
+			v.fail(path+".type", "must be one of %s, %s, %s", logger.SinkStdoutPretty, logger.SinkStdoutJSON, logger.SinkFile)
+		}
+
+		if sink.Level != "" {
+			if _, err := logger.ParseLevel(sink.Level); err != nil {
+				v.fail(path+".level", "%v", err)
+			}
+		}
+	}
+
+	if c.Sampling.Enabled {
+		v.positive("log.sampling.window", c.Sampling.Window)
+		for level, limit := range c.Sampling.Levels {
+			path := "log.sampling.levels." + level
+			if _, err := logger.ParseLevel(level); err != nil {
+				v.fail(path, "%v", err)
+			}
+			// With no burst, all records of the level would be suppressed.
+			if limit.Burst < 1 {
+				v.fail(path+".burst", "must be at least 1")
+			}
+			if limit.Rate < 0 {
+				v.fail(path+".rate", "must not be negative")
+			}
+		}
+	}
+}
+
+// required checks that the values of the fields with env-required tag are set.
+func (v *validator) required(root reflect.Value) {
+	_ = walk(root, "", func(field reflect.StructField, value reflect.Value, path string) error {
+		if field.Tag.Get("env-required") == "true" && value.IsZero() {
+			v.fail(path, "is required")
+		}
+
+		return nil
+	})
+}
+
+func (v *validator) port(path, port string) {
+	n, err := strconv.Atoi(port)
+	if err != nil || n < 1 || n > 65535 {
+		v.fail(path, "must be a port between 1 and 65535, got %q", port)
+	}
+}
+
+func (v *validator) positive(path string, d time.Duration) {
+	if d <= 0 {
+		v.fail(path, "must be positive, got %s", d)
+	}
+}
+
+func (v *validator) notNegative(path string, d time.Duration) {
+	if d < 0 {
+		v.fail(path, "must not be negative, got %s", d)
+	}
+}
+
+func (v *validator) limit(path string, limit LimitConfig) {
+	// Only zero turns the limit off, so that a negative or broken rps is not taken for no limit.
+	if math.IsNaN(limit.RPS) || math.IsInf(limit.RPS, 0) || limit.RPS < 0 {
+		v.fail(path+".rps", "must be a positive number, or 0 for no limit, got %g", limit.RPS)
+	}
+	switch {
+	case limit.Burst < 0:
+		v.fail(path+".burst", "must not be negative, got %d", limit.Burst)
+	case limit.RPS > 0 && limit.Burst == 0:
+		v.fail(path+".burst", "must be positive when rps is set")
+	}
+}
+
+func (v *validator) featureFlag(path string, flag FeatureFlagConfig) {
+	if math.IsNaN(flag.Percentage) || flag.Percentage < 0 || flag.Percentage > 100 {
+		v.fail(path+".percentage", "must be between 0 and 100, got %g", flag.Percentage)
+	}
+	for _, externalID := range flag.AllowList {
+		if externalID <= 0 {
+			v.fail(path+".allow_list", "external IDs must be positive, got %d", externalID)
+		}
+	}
+}
+
+func (v *validator) file(path, file string) {
+	if file == "" {
+		v.fail(path, "is required")
+		return
+	}
+
+	if _, err := os.Stat(file); err != nil {
+		v.fail(path, "%v", err)
+	}
+}
+
+// writableDir checks that the directory exists and has write permission. Only the permission bits are checked,
+// so that validation writes nothing; a directory the process user can't write to fails when the file is opened.
+func (v *validator) writableDir(path, dir string) {
+	info, err := os.Stat(dir)
+	if err != nil {
+		v.fail(path, "directory %s: %v", dir, err)
+		return
+	}
+	if !info.IsDir() {
+		v.fail(path, "%s is not a directory", dir)
+		return
+	}
+
+	if info.Mode().Perm()&0o222 == 0 {
+		v.fail(path, "directory %s is not writable: mode %s", dir, info.Mode().Perm())
+	}
+}
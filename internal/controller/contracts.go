@@ -2,7 +2,9 @@ package controller
 
 import (
 	"context"
+	"love-signal-users/internal/dto"
 	"love-signal-users/internal/entity"
+	"love-signal-users/pkg/eventbus"
 )
 
 type (
@@ -17,6 +19,24 @@ type (
 		Execute(ctx context.Context, externalID int64) (entity.User, error)
 	}
 
+	// UserProfile is a use-case for getting user data with the extended profile.
+	UserProfile interface {
+		// Execute executes the use-case for getting user data with bio, city and interests.
+		Execute(ctx context.Context, id int64) (entity.User, error)
+	}
+
+	// UpdateUserProfile is a use-case for updating the extended profile of users.
+	UpdateUserProfile interface {
+		// Execute executes the use-case for replacing the extended profile of the user.
+		Execute(ctx context.Context, userID int64, profile dto.UserProfile) (entity.User, error)
+	}
+
+	// InterestTags is a use-case for getting the dictionary of interest tags.
+	InterestTags interface {
+		// Execute executes the use-case for getting the names of the interest tags.
+		Execute(ctx context.Context) ([]string, error)
+	}
+
 	// Followed is a use-case for getting followed users.
 	Followed interface {
 		// Execute executes the use-case for getting followed users.
@@ -34,4 +54,34 @@ type (
 		// Execute executes the use-case for unfollowing user.
 		Execute(ctx context.Context, followLinkID int64) error
 	}
+
+	// DeactivateUser is a use-case for deactivating users.
+	DeactivateUser interface {
+		// Execute executes the use-case for deactivating user.
+		Execute(ctx context.Context, userID int64) error
+	}
+
+	// RestoreUser is a use-case for restoring deactivated users.
+	RestoreUser interface {
+		// Execute executes the use-case for restoring deactivated user.
+		Execute(ctx context.Context, userID int64) error
+	}
+
+	// Stats is a use-case for getting the numbers of users, follows and likes and the size of the storage.
+	Stats interface {
+		// Execute executes the use-case for getting stats.
+		Execute(ctx context.Context) (entity.Stats, error)
+	}
+
+	// UserArchive is a use-case for building the archive of everything stored about a user.
+	UserArchive interface {
+		// Execute executes the use-case for building the archive of the user with the external ID.
+		Execute(ctx context.Context, externalID int64) (entity.UserArchive, error)
+	}
+
+	// FollowEvents is a use-case for subscribing to follow events.
+	FollowEvents interface {
+		// Execute executes the use-case for subscribing to follow events of the user.
+		Execute(ctx context.Context, userID int64) (*eventbus.Subscription[entity.FollowEvent], error)
+	}
 )
@@ -0,0 +1,146 @@
+// Package document contains the documents the service hands out as files.
+package document
+
+import (
+	"encoding/json"
+	"love-signal-users/internal/entity"
+	"love-signal-users/internal/enum"
+	"strconv"
+	"time"
+)
+
+// UserArchiveVersion is the version of the user archive document. It is increased on incompatible changes.
+const UserArchiveVersion = 1
+
+// UserArchive is the versioned JSON document of everything stored about users, keyed by their external IDs.
+type UserArchive struct {
+	Version     int                     `json:"version"`
+	GeneratedAt time.Time               `json:"generatedAt"`
+	Users       map[string]ArchivedUser `json:"users"`
+}
+
+// ArchivedUser is the data of a user in the user archive document.
+type ArchivedUser struct {
+	Profile   Profile          `json:"profile"`
+	Following []ArchivedFollow `json:"following"`
+	Followers []ArchivedFollow `json:"followers"`
+	History   []HistoryRecord  `json:"history"`
+}
+
+// Profile is the stored fields of a user.
+type Profile struct {
+	ExternalID    int64     `json:"externalId"`
+	FullName      string    `json:"fullName"`
+	DateOfBirth   *string   `json:"dateOfBirth"`
+	Gender        *string   `json:"gender"`
+	AvatarFileKey *string   `json:"avatarFileKey"`
+	Bio           *string   `json:"bio"`
+	City          *string   `json:"city"`
+	Interests     []string  `json:"interests"`
+	Deleted       bool      `json:"deleted"`
+	CreatedAt     time.Time `json:"createdAt"`
+	UpdatedAt     time.Time `json:"updatedAt"`
+}
+
+// ArchivedFollow is a follow link with the other user identified by external ID.
+type ArchivedFollow struct {
+	UserExternalID int64     `json:"userExternalId"`
+	NumberOfLikes  uint32    `json:"numberOfLikes"`
+	CreatedAt      time.Time `json:"createdAt"`
+	UpdatedAt      time.Time `json:"updatedAt"`
+}
+
+// HistoryRecord is an audit record of a call involving the user. Actors and targets are references
+// like user:<ID> with the internal IDs of the service.
+type HistoryRecord struct {
+	Time      time.Time `json:"time"`
+	Action    string    `json:"action"`
+	Actor     string    `json:"actor,omitempty"`
+	Caller    string    `json:"caller,omitempty"`
+	Target    string    `json:"target"`
+	Result    string    `json:"result"`
+	Error     string    `json:"error,omitempty"`
+	RequestID string    `json:"requestId,omitempty"`
+}
+
+// NewUserArchive returns the user archive document of the archive.
+func NewUserArchive(archive entity.UserArchive) UserArchive {
+	var dateOfBirth *string
+	if archive.DateOfBirth != nil {
+		value := archive.DateOfBirth.Format(time.DateOnly)
+		dateOfBirth = &value
+	}
+
+	var gender *string
+	if archive.Gender != nil {
+		value := genderName(*archive.Gender)
+		gender = &value
+	}
+
+	user := ArchivedUser{
+		Profile: Profile{
+			ExternalID:    archive.ExternalID,
+			FullName:      archive.FullName,
+			DateOfBirth:   dateOfBirth,
+			Gender:        gender,
+			AvatarFileKey: archive.AvatarFileKey,
+			Bio:           archive.Bio,
+			City:          archive.City,
+			Interests:     archive.Interests,
+			Deleted:       archive.Deleted,
+			CreatedAt:     archive.CreatedAt,
+			UpdatedAt:     archive.UpdatedAt,
+		},
+		Following: toArchivedFollows(archive.Following),
+		Followers: toArchivedFollows(archive.Followers),
+		History:   make([]HistoryRecord, 0, len(archive.History)),
+	}
+	for _, rec := range archive.History {
+		user.History = append(user.History, HistoryRecord{
+			Time:      rec.Time,
+			Action:    rec.Action,
+			Actor:     rec.Actor,
+			Caller:    rec.Caller,
+			Target:    rec.Target,
+			Result:    rec.Result,
+			Error:     rec.Error,
+			RequestID: rec.RequestID,
+		})
+	}
+
+	return UserArchive{
+		Version:     UserArchiveVersion,
+		GeneratedAt: archive.GeneratedAt.UTC(),
+		Users:       map[string]ArchivedUser{strconv.FormatInt(archive.ExternalID, 10): user},
+	}
+}
+
+// MarshalUserArchive returns the user archive document of the archive as indented JSON.
+func MarshalUserArchive(archive entity.UserArchive) ([]byte, error) {
+	return json.MarshalIndent(NewUserArchive(archive), "", "  ")
+}
+
+func toArchivedFollows(follows []entity.ArchivedFollow) []ArchivedFollow {
+	result := make([]ArchivedFollow, 0, len(follows))
+	for _, follow := range follows {
+		result = append(result, ArchivedFollow{
+			UserExternalID: follow.UserExternalID,
+			NumberOfLikes:  follow.NumberOfLikes,
+			CreatedAt:      follow.CreatedAt,
+			UpdatedAt:      follow.UpdatedAt,
+		})
+	}
+
+	return result
+}
+
+func genderName(gender enum.Gender) string {
+	switch gender {
+	case enum.MALE:
+		return "male"
+	case enum.FEMALE:
+		return "female"
+	default:
+		return "unspecified"
+	}
+}
@@ -19,3 +19,18 @@ func InternalError(msg string) error {
 func NotFoundError(msg string) error {
 	return status.Error(codes.NotFound, msg)
 }
+
+// AlreadyExistsError returns an error with gRPC code AlreadyExists and message.
+func AlreadyExistsError(msg string) error {
+	return status.Error(codes.AlreadyExists, msg)
+}
+
+// ResourceExhaustedError returns an error with gRPC code ResourceExhausted and message.
+func ResourceExhaustedError(msg string) error {
+	return status.Error(codes.ResourceExhausted, msg)
+}
+
+// UnavailableError returns an error with gRPC code Unavailable and message.
+func UnavailableError(msg string) error {
+	return status.Error(codes.Unavailable, msg)
+}
@@ -4,6 +4,7 @@ import (
 	"google.golang.org/grpc"
 	"love-signal-users/internal/controller"
 	v1 "love-signal-users/internal/controller/grpc/v1"
+	"time"
 )
 
 // NewRouter creates a new router for the gRPC server controller.
@@ -14,6 +15,12 @@ func NewRouter(
 	followedUsersUseCase controller.Followed,
 	followUserUseCase controller.Follow,
 	unfollowUserUseCase controller.Unfollow,
+	followEventsUseCase controller.FollowEvents,
+	followEventsKeepalive time.Duration,
+	deactivateUserUseCase controller.DeactivateUser,
+	restoreUserUseCase controller.RestoreUser,
+	statsUseCase controller.Stats,
+	userArchiveUseCase controller.UserArchive,
 ) {
 	v1.NewRoutes(
 		server,
@@ -22,5 +29,11 @@ func NewRouter(
 		followedUsersUseCase,
 		followUserUseCase,
 		unfollowUserUseCase,
+		followEventsUseCase,
+		followEventsKeepalive,
+		deactivateUserUseCase,
+		restoreUserUseCase,
+		statsUseCase,
+		userArchiveUseCase,
 	)
 }
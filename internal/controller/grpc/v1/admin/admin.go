@@ -0,0 +1,126 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"google.golang.org/grpc"
+	lsadminpb "love-signal-users/gen/go/admin"
+	"love-signal-users/internal/controller"
+	"love-signal-users/internal/controller/document"
+	"love-signal-users/internal/controller/grpc/response"
+	"love-signal-users/internal/usecase"
+)
+
+const (
+	emptyValue = 0
+)
+
+type serverAPI struct {
+	lsadminpb.UnimplementedAdminServer
+	deactivateUserUseCase controller.DeactivateUser
+	restoreUserUseCase    controller.RestoreUser
+	statsUseCase          controller.Stats
+	userArchiveUseCase    controller.UserArchive
+}
+
+// RegisterAdminServer registers the implementation of the admin API service with the gRPC server.
+func RegisterAdminServer(
+	gRPC *grpc.Server,
+	deactivateUserUseCase controller.DeactivateUser,
+	restoreUserUseCase controller.RestoreUser,
+	statsUseCase controller.Stats,
+	userArchiveUseCase controller.UserArchive,
+) {
+	api := &serverAPI{
+		deactivateUserUseCase: deactivateUserUseCase,
+		restoreUserUseCase:    restoreUserUseCase,
+		statsUseCase:          statsUseCase,
+		userArchiveUseCase:    userArchiveUseCase,
+	}
+	lsadminpb.RegisterAdminServer(gRPC, api)
+}
+
+// DeactivateUser deactivates a user by their ID.
+func (s *serverAPI) DeactivateUser(
+	ctx context.Context,
+	req *lsadminpb.DeactivateUserRequest,
+) (*lsadminpb.DeactivateUserResponse, error) {
+	if req.GetUserId() == emptyValue {
+		return nil, response.InvalidArgumentError("user id is empty")
+	}
+
+	if err := s.deactivateUserUseCase.Execute(ctx, req.GetUserId()); err != nil {
+		if errors.Is(err, usecase.ErrUserNotFound) {
+			return nil, response.NotFoundError("user not found")
+		}
+
+		return nil, response.InternalError("error deactivating user")
+	}
+
+	return &lsadminpb.DeactivateUserResponse{Success: true}, nil
+}
+
+// RestoreUser restores a deactivated user by their ID.
+func (s *serverAPI) RestoreUser(
+	ctx context.Context,
+	req *lsadminpb.RestoreUserRequest,
+) (*lsadminpb.RestoreUserResponse, error) {
+	if req.GetUserId() == emptyValue {
+		return nil, response.InvalidArgumentError("user id is empty")
+	}
+
+	if err := s.restoreUserUseCase.Execute(ctx, req.GetUserId()); err != nil {
+		if errors.Is(err, usecase.ErrUserNotFound) {
+			return nil, response.NotFoundError("user not found")
+		}
+
+		return nil, response.InternalError("error restoring user")
+	}
+
+	return &lsadminpb.RestoreUserResponse{Success: true}, nil
+}
+
+// GetStats returns the numbers of users, follows and likes and the size of the storage.
+func (s *serverAPI) GetStats(
+	ctx context.Context,
+	_ *lsadminpb.GetStatsRequest,
+) (*lsadminpb.StatsResponse, error) {
+	stats, err := s.statsUseCase.Execute(ctx)
+	if err != nil {
+		return nil, response.InternalError("error getting stats")
+	}
+
+	return &lsadminpb.StatsResponse{
+		Users:        stats.Users,
+		DeletedUsers: stats.DeletedUsers,
+		Follows:      stats.Follows,
+		Likes:        stats.Likes,
+		SizeBytes:    stats.SizeBytes,
+	}, nil
+}
+
+// ExportUserData returns the versioned JSON document of everything stored about a user by their external ID.
+func (s *serverAPI) ExportUserData(
+	ctx context.Context,
+	req *lsadminpb.ExportUserDataRequest,
+) (*lsadminpb.ExportUserDataResponse, error) {
+	if req.GetUserExternalId() == emptyValue {
+		return nil, response.InvalidArgumentError("user external id is empty")
+	}
+
+	archive, err := s.userArchiveUseCase.Execute(ctx, req.GetUserExternalId())
+	if err != nil {
+		if errors.Is(err, usecase.ErrUserNotFound) {
+			return nil, response.NotFoundError("user not found")
+		}
+
+		return nil, response.InternalError("error exporting user data")
+	}
+
+	doc, err := document.MarshalUserArchive(archive)
+	if err != nil {
+		return nil, response.InternalError("error exporting user data")
+	}
+
+	return &lsadminpb.ExportUserDataResponse{Document: doc}, nil
+}
@@ -0,0 +1,116 @@
+package events
+
+import (
+	"errors"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	lseventspb "love-signal-users/gen/go/events"
+	"love-signal-users/internal/controller"
+	"love-signal-users/internal/controller/grpc/response"
+	"love-signal-users/internal/entity"
+	"love-signal-users/internal/usecase"
+	"love-signal-users/pkg/eventbus"
+	"time"
+)
+
+const (
+	emptyValue = 0
+)
+
+type serverAPI struct {
+	lseventspb.UnimplementedFollowEventsServer
+	followEventsUseCase controller.FollowEvents
+	keepaliveInterval   time.Duration
+}
+
+// RegisterFollowEventsServer registers the implementation of the follow events API service with the gRPC server.
+// A keepalive event is sent to the subscriber every keepaliveInterval.
+func RegisterFollowEventsServer(
+	gRPC *grpc.Server,
+	followEventsUseCase controller.FollowEvents,
+	keepaliveInterval time.Duration,
+) {
+	api := &serverAPI{
+		followEventsUseCase: followEventsUseCase,
+		keepaliveInterval:   keepaliveInterval,
+	}
+	lseventspb.RegisterFollowEventsServer(gRPC, api)
+}
+
+// SubscribeFollowEvents streams follow events of the user until the client cancels the call.
+func (s *serverAPI) SubscribeFollowEvents(
+	req *lseventspb.SubscribeFollowEventsRequest,
+	stream grpc.ServerStreamingServer[lseventspb.FollowEvent],
+) error {
+	if err := validateSubscribeFollowEventsRequest(req); err != nil {
+		return err
+	}
+
+	ctx := stream.Context()
+
+	subscription, err := s.followEventsUseCase.Execute(ctx, req.GetUserId())
+	if err != nil {
+		if errors.Is(err, usecase.ErrUserNotFound) {
+			return response.NotFoundError("user not found")
+		}
+
+		return response.InternalError("error subscribing to follow events")
+	}
+	defer subscription.Unsubscribe()
+
+	keepalive := time.NewTicker(s.keepaliveInterval)
+	defer keepalive.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event, ok := <-subscription.Events():
+			if !ok {
+				return subscriptionEndedError(subscription.Err())
+			}
+
+			if err = stream.Send(toFollowEventPb(event)); err != nil {
+				return err
+			}
+		case <-keepalive.C:
+			keepaliveEventPb := &lseventspb.FollowEvent{
+				Type:       lseventspb.FollowEventType_FOLLOW_EVENT_TYPE_KEEPALIVE,
+				UserId:     req.GetUserId(),
+				OccurredAt: timestamppb.Now(),
+			}
+			if err = stream.Send(keepaliveEventPb); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func validateSubscribeFollowEventsRequest(req *lseventspb.SubscribeFollowEventsRequest) error {
+	if req.GetUserId() == emptyValue {
+		return response.InvalidArgumentError("user id is empty")
+	}
+
+	return nil
+}
+
+func subscriptionEndedError(err error) error {
+	switch {
+	case errors.Is(err, eventbus.ErrSlowSubscriber):
+		return response.ResourceExhaustedError("subscriber does not keep up with events")
+	case errors.Is(err, eventbus.ErrClosed):
+		return response.UnavailableError("server is stopping")
+	default:
+		return nil
+	}
+}
+
+func toFollowEventPb(event entity.FollowEvent) *lseventspb.FollowEvent {
+	return &lseventspb.FollowEvent{
+		Type:         lseventspb.FollowEventType(event.Type),
+		UserId:       event.UserID,
+		ActorUserId:  event.ActorUserID,
+		FollowLinkId: event.FollowLinkID,
+		OccurredAt:   timestamppb.New(event.OccurredAt),
+	}
+}
@@ -3,7 +3,10 @@ package v1
 import (
 	"google.golang.org/grpc"
 	"love-signal-users/internal/controller"
+	"love-signal-users/internal/controller/grpc/v1/admin"
+	"love-signal-users/internal/controller/grpc/v1/events"
 	"love-signal-users/internal/controller/grpc/v1/users"
+	"time"
 )
 
 // NewRoutes creates a new routes for the gRPC server controller of version 1.
@@ -14,6 +17,12 @@ func NewRoutes(
 	followedUsersUseCase controller.Followed,
 	followUserUseCase controller.Follow,
 	unfollowUserUseCase controller.Unfollow,
+	followEventsUseCase controller.FollowEvents,
+	followEventsKeepalive time.Duration,
+	deactivateUserUseCase controller.DeactivateUser,
+	restoreUserUseCase controller.RestoreUser,
+	statsUseCase controller.Stats,
+	userArchiveUseCase controller.UserArchive,
 ) {
 	users.RegisterUsersServer(
 		server,
@@ -23,4 +32,16 @@ func NewRoutes(
 		followUserUseCase,
 		unfollowUserUseCase,
 	)
+	events.RegisterFollowEventsServer(
+		server,
+		followEventsUseCase,
+		followEventsKeepalive,
+	)
+	admin.RegisterAdminServer(
+		server,
+		deactivateUserUseCase,
+		restoreUserUseCase,
+		statsUseCase,
+		userArchiveUseCase,
+	)
 }
@@ -207,6 +207,9 @@ func (s *serverAPI) FollowUser(
 		if errors.Is(err, usecase.ErrUserNotFound) {
 			return nil, response.NotFoundError("user not found")
 		}
+		if errors.Is(err, usecase.ErrFollowExists) {
+			return nil, response.AlreadyExistsError("follow already exists")
+		}
 
 		return &lsuserspb.FollowUserResponse{Success: false}, response.InternalError("error following user")
 	}
@@ -237,6 +240,10 @@ func (s *serverAPI) UnfollowUser(
 
 	err := s.unfollowUserUseCase.Execute(ctx, req.GetFollowLinkId())
 	if err != nil {
+		if errors.Is(err, usecase.ErrFollowNotFound) {
+			return nil, response.NotFoundError("follow not found")
+		}
+
 		return &lsuserspb.UnfollowUserResponse{Success: false}, response.InternalError("error unfollowing user")
 	}
 
@@ -0,0 +1,183 @@
+package admin
+
+import (
+	"context"
+	"fmt"
+	"love-signal-users/internal/controller/http/response"
+	"net/http"
+	"net/http/pprof"
+	"runtime/debug"
+	"time"
+)
+
+// Storage is the storage checked by the readiness probe.
+type Storage interface {
+	// Ping checks that the storage is accessible.
+	Ping(ctx context.Context) error
+	// MigrationVersion returns the version of the applied migrations and whether the database is dirty.
+	MigrationVersion(ctx context.Context, migrationsTable string) (uint, bool, error)
+}
+
+// Readiness reports whether the application serves calls.
+type Readiness interface {
+	// Ready reports false before the application has started and once it is shutting down.
+	Ready() bool
+}
+
+// Reloader reloads the configuration.
+type Reloader interface {
+	// Reload re-reads the config and applies the changes that can be made at runtime.
+	// It returns the paths of the applied changes and of the changes that require a restart.
+	Reload() (applied []string, restartRequired []string, err error)
+}
+
+// Status body of the probes.
+type statusBody struct {
+	Status           string `json:"status"`
+	MigrationVersion *uint  `json:"migrationVersion,omitempty"`
+	Error            string `json:"error,omitempty"`
+}
+
+// Reload body.
+type reloadBody struct {
+	Status          string   `json:"status"`
+	Applied         []string `json:"applied"`
+	RestartRequired []string `json:"restartRequired,omitempty"`
+	Error           string   `json:"error,omitempty"`
+}
+
+// Build info body.
+type buildInfoBody struct {
+	GoVersion   string `json:"goVersion"`
+	Path        string `json:"path"`
+	Version     string `json:"version"`
+	VCS         string `json:"vcs,omitempty"`
+	VCSRevision string `json:"vcsRevision,omitempty"`
+	VCSTime     string `json:"vcsTime,omitempty"`
+	VCSModified bool   `json:"vcsModified"`
+}
+
+const (
+	statusOK          = "ok"
+	statusUnavailable = "unavailable"
+	statusReloaded    = "reloaded"
+	statusRejected    = "rejected"
+)
+
+// NewRouter returns the handler of the admin endpoints: pprof, liveness and readiness probes, build info
+// and config reload.
+func NewRouter(
+	storage Storage,
+	readiness Readiness,
+	reloader Reloader,
+	migrationsTable string,
+	readyTimeout time.Duration,
+) http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+
+	mux.HandleFunc("GET /healthz", healthz)
+	mux.HandleFunc("GET /readyz", readyz(storage, readiness, migrationsTable, readyTimeout))
+	mux.HandleFunc("GET /buildinfo", buildInfo)
+	mux.HandleFunc("POST /reload", reload(reloader))
+
+	return mux
+}
+
+// healthz reports that the process is alive.
+func healthz(w http.ResponseWriter, _ *http.Request) {
+	response.OK(w, statusBody{Status: statusOK})
+}
+
+// readyz reports whether the service is ready to serve: the application is running,
+// the storage is accessible and migrations are applied.
+func readyz(storage Storage, readiness Readiness, migrationsTable string, timeout time.Duration) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !readiness.Ready() {
+			response.JSON(w, http.StatusServiceUnavailable, statusBody{
+				Status: statusUnavailable,
+				Error:  "application is starting or shutting down",
+			})
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(r.Context(), timeout)
+		defer cancel()
+
+		if err := storage.Ping(ctx); err != nil {
+			response.JSON(w, http.StatusServiceUnavailable, statusBody{Status: statusUnavailable, Error: err.Error()})
+			return
+		}
+
+		version, dirty, err := storage.MigrationVersion(ctx, migrationsTable)
+		if err != nil {
+			response.JSON(w, http.StatusServiceUnavailable, statusBody{Status: statusUnavailable, Error: err.Error()})
+			return
+		}
+		if dirty {
+			response.JSON(w, http.StatusServiceUnavailable, statusBody{
+				Status:           statusUnavailable,
+				MigrationVersion: &version,
+				Error:            fmt.Sprintf("migration %d has failed, database is dirty", version),
+			})
+			return
+		}
+
+		response.OK(w, statusBody{Status: statusOK, MigrationVersion: &version})
+	}
+}
+
+// reload reloads the config. Invalid config is rejected and nothing is applied.
+func reload(reloader Reloader) http.HandlerFunc {
+	return func(w http.ResponseWriter, _ *http.Request) {
+		applied, restartRequired, err := reloader.Reload()
+		if err != nil {
+			response.JSON(w, http.StatusUnprocessableEntity, reloadBody{
+				Status:  statusRejected,
+				Applied: []string{},
+				Error:   err.Error(),
+			})
+			return
+		}
+
+		if applied == nil {
+			applied = []string{}
+		}
+
+		response.OK(w, reloadBody{Status: statusReloaded, Applied: applied, RestartRequired: restartRequired})
+	}
+}
+
+// buildInfo reports the version of the binary.
+func buildInfo(w http.ResponseWriter, _ *http.Request) {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		response.InternalError(w, "build info is not available")
+		return
+	}
+
+	body := buildInfoBody{
+		GoVersion: info.GoVersion,
+		Path:      info.Path,
+		Version:   info.Main.Version,
+	}
+	for _, setting := range info.Settings {
+		switch setting.Key {
+		case "vcs":
+			body.VCS = setting.Value
+		case "vcs.revision":
+			body.VCSRevision = setting.Value
+		case "vcs.time":
+			body.VCSTime = setting.Value
+		case "vcs.modified":
+			body.VCSModified = setting.Value == "true"
+		}
+	}
+
+	response.OK(w, body)
+}
@@ -0,0 +1,52 @@
+package response
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// Error codes of the HTTP API. They match the gRPC codes of the same errors in the gRPC API.
+const (
+	CodeInvalidArgument = "INVALID_ARGUMENT"
+	CodeNotFound        = "NOT_FOUND"
+	CodeAlreadyExists   = "ALREADY_EXISTS"
+	CodeInternal        = "INTERNAL"
+)
+
+// ErrorBody is the body of the error response.
+type ErrorBody struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// JSON writes the value as JSON response with the status code.
+func JSON(w http.ResponseWriter, statusCode int, value any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	_ = json.NewEncoder(w).Encode(value)
+}
+
+// OK writes the value as JSON response with status code 200.
+func OK(w http.ResponseWriter, value any) {
+	JSON(w, http.StatusOK, value)
+}
+
+// InvalidArgumentError writes an error response with status code 400 and message.
+func InvalidArgumentError(w http.ResponseWriter, msg string) {
+	JSON(w, http.StatusBadRequest, ErrorBody{Code: CodeInvalidArgument, Message: msg})
+}
+
+// InternalError writes an error response with status code 500 and message.
+func InternalError(w http.ResponseWriter, msg string) {
+	JSON(w, http.StatusInternalServerError, ErrorBody{Code: CodeInternal, Message: msg})
+}
+
+// NotFoundError writes an error response with status code 404 and message.
+func NotFoundError(w http.ResponseWriter, msg string) {
+	JSON(w, http.StatusNotFound, ErrorBody{Code: CodeNotFound, Message: msg})
+}
+
+// AlreadyExistsError writes an error response with status code 409 and message.
+func AlreadyExistsError(w http.ResponseWriter, msg string) {
+	JSON(w, http.StatusConflict, ErrorBody{Code: CodeAlreadyExists, Message: msg})
+}
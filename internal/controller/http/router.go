@@ -0,0 +1,35 @@
+package http
+
+import (
+	"love-signal-users/internal/controller"
+	v1 "love-signal-users/internal/controller/http/v1"
+	"net/http"
+)
+
+// NewRouter creates a new router for the HTTP server controller.
+func NewRouter(
+	userDataUseCase controller.UserData,
+	userDataByExternalIDUseCase controller.UserDataByExternalID,
+	followedUsersUseCase controller.Followed,
+	followUserUseCase controller.Follow,
+	unfollowUserUseCase controller.Unfollow,
+	userProfileUseCase controller.UserProfile,
+	updateUserProfileUseCase controller.UpdateUserProfile,
+	interestTagsUseCase controller.InterestTags,
+) http.Handler {
+	mux := http.NewServeMux()
+
+	v1.NewRoutes(
+		mux,
+		userDataUseCase,
+		userDataByExternalIDUseCase,
+		followedUsersUseCase,
+		followUserUseCase,
+		unfollowUserUseCase,
+		userProfileUseCase,
+		updateUserProfileUseCase,
+		interestTagsUseCase,
+	)
+
+	return mux
+}
@@ -0,0 +1,44 @@
+package v1
+
+import (
+	_ "embed"
+	"love-signal-users/internal/controller"
+	"love-signal-users/internal/controller/http/v1/users"
+	"net/http"
+)
+
+const prefix = "/api/v1"
+
+//go:embed openapi.yaml
+var openAPIDocument []byte
+
+// NewRoutes creates a new routes for the HTTP server controller of version 1.
+func NewRoutes(
+	mux *http.ServeMux,
+	userDataUseCase controller.UserData,
+	userDataByExternalIDUseCase controller.UserDataByExternalID,
+	followedUsersUseCase controller.Followed,
+	followUserUseCase controller.Follow,
+	unfollowUserUseCase controller.Unfollow,
+	userProfileUseCase controller.UserProfile,
+	updateUserProfileUseCase controller.UpdateUserProfile,
+	interestTagsUseCase controller.InterestTags,
+) {
+	users.RegisterUsersRoutes(
+		mux,
+		prefix,
+		userDataUseCase,
+		userDataByExternalIDUseCase,
+		followedUsersUseCase,
+		followUserUseCase,
+		unfollowUserUseCase,
+		userProfileUseCase,
+		updateUserProfileUseCase,
+		interestTagsUseCase,
+	)
+
+	mux.HandleFunc("GET "+prefix+"/openapi.yaml", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/yaml")
+		_, _ = w.Write(openAPIDocument)
+	})
+}
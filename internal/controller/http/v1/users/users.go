@@ -0,0 +1,430 @@
+package users
+
+import (
+	"encoding/json"
+	"errors"
+	"love-signal-users/internal/controller"
+	"love-signal-users/internal/controller/http/response"
+	"love-signal-users/internal/dto"
+	"love-signal-users/internal/entity"
+	"love-signal-users/internal/enum"
+	"love-signal-users/internal/usecase"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	emptyValue = 0
+)
+
+// maxRequestBodySize is the limit of the size of JSON request bodies, in bytes.
+const maxRequestBodySize = 1 << 20
+
+// includeProfile is the value of the include query parameter that adds the extended profile to user data.
+const includeProfile = "profile"
+
+// Gender values of the HTTP API.
+const (
+	genderUnspecified = "GENDER_UNSPECIFIED"
+	genderMale        = "GENDER_MALE"
+	genderFemale      = "GENDER_FEMALE"
+)
+
+type serverAPI struct {
+	userDataUseCase             controller.UserData
+	userDataByExternalIDUseCase controller.UserDataByExternalID
+	followedUsersUseCase        controller.Followed
+	followUserUseCase           controller.Follow
+	unfollowUserUseCase         controller.Unfollow
+	userProfileUseCase          controller.UserProfile
+	updateUserProfileUseCase    controller.UpdateUserProfile
+	interestTagsUseCase         controller.InterestTags
+}
+
+// UserDataResponse is a response with information about a user. The profile is included on request.
+type UserDataResponse struct {
+	ID            int64            `json:"id"`
+	FullName      string           `json:"fullName"`
+	DateOfBirth   *time.Time       `json:"dateOfBirth"`
+	Gender        string           `json:"gender"`
+	AvatarFileKey *string          `json:"avatarFileKey"`
+	Profile       *ProfileResponse `json:"profile,omitempty"`
+}
+
+// ProfileResponse is a response with the extended profile of a user.
+type ProfileResponse struct {
+	Bio       *string  `json:"bio"`
+	City      *string  `json:"city"`
+	Interests []string `json:"interests"`
+}
+
+// UpdateProfileRequest is a request for replacing the extended profile of a user.
+// Missing or empty bio and city are cleared.
+type UpdateProfileRequest struct {
+	Bio       *string  `json:"bio"`
+	City      *string  `json:"city"`
+	Interests []string `json:"interests"`
+}
+
+// InterestTagsResponse is a response with the interest tags users can choose from.
+type InterestTagsResponse struct {
+	Tags []string `json:"tags"`
+}
+
+// FollowedUsersResponse is a response with a list of followed users.
+type FollowedUsersResponse struct {
+	Users []FollowedUser `json:"users"`
+}
+
+// FollowedUser is information about a followed user.
+type FollowedUser struct {
+	FollowLinkID  int64   `json:"followLinkId"`
+	UserID        int64   `json:"userId"`
+	FullName      string  `json:"fullName"`
+	AvatarFileKey *string `json:"avatarFileKey"`
+	NumberOfLikes uint32  `json:"numberOfLikes"`
+}
+
+// FollowUserRequest is a request for following a user.
+type FollowUserRequest struct {
+	UserIDToFollow int64 `json:"userIdToFollow"`
+}
+
+// SuccessResponse is a response of a mutating operation.
+type SuccessResponse struct {
+	Success bool `json:"success"`
+}
+
+// RegisterUsersRoutes registers the handlers of the users API on the mux.
+func RegisterUsersRoutes(
+	mux *http.ServeMux,
+	prefix string,
+	userDataUseCase controller.UserData,
+	userDataByExternalIDUseCase controller.UserDataByExternalID,
+	followedUsersUseCase controller.Followed,
+	followUserUseCase controller.Follow,
+	unfollowUserUseCase controller.Unfollow,
+	userProfileUseCase controller.UserProfile,
+	updateUserProfileUseCase controller.UpdateUserProfile,
+	interestTagsUseCase controller.InterestTags,
+) {
+	api := &serverAPI{
+		userDataUseCase:             userDataUseCase,
+		userDataByExternalIDUseCase: userDataByExternalIDUseCase,
+		followedUsersUseCase:        followedUsersUseCase,
+		followUserUseCase:           followUserUseCase,
+		unfollowUserUseCase:         unfollowUserUseCase,
+		userProfileUseCase:          userProfileUseCase,
+		updateUserProfileUseCase:    updateUserProfileUseCase,
+		interestTagsUseCase:         interestTagsUseCase,
+	}
+
+	mux.HandleFunc("GET "+prefix+"/users/{userId}", api.GetUserData)
+	mux.HandleFunc("GET "+prefix+"/external-users/{userExternalId}", api.GetUserDataByExternalID)
+	mux.HandleFunc("GET "+prefix+"/users/{userId}/followed", api.GetFollowedUsers)
+	mux.HandleFunc("POST "+prefix+"/users/{userId}/followed", api.FollowUser)
+	mux.HandleFunc("DELETE "+prefix+"/follows/{followLinkId}", api.UnfollowUser)
+	mux.HandleFunc("GET "+prefix+"/users/{userId}/profile", api.GetUserProfile)
+	mux.HandleFunc("PUT "+prefix+"/users/{userId}/profile", api.UpdateUserProfile)
+	mux.HandleFunc("GET "+prefix+"/interest-tags", api.GetInterestTags)
+}
+
+// GetUserData returns information about a user by their ID, with the extended profile if it is included.
+func (s *serverAPI) GetUserData(w http.ResponseWriter, r *http.Request) {
+	userID, ok := pathID(r, "userId")
+	if !ok {
+		response.InvalidArgumentError(w, "user id is invalid")
+		return
+	}
+
+	withProfile, ok := includesProfile(r)
+	if !ok {
+		response.InvalidArgumentError(w, "include is invalid")
+		return
+	}
+
+	var userData entity.User
+	var err error
+	if withProfile {
+		userData, err = s.userProfileUseCase.Execute(r.Context(), userID)
+	} else {
+		userData, err = s.userDataUseCase.Execute(r.Context(), userID)
+	}
+	if err != nil {
+		if errors.Is(err, usecase.ErrUserNotFound) {
+			response.NotFoundError(w, "user not found")
+			return
+		}
+
+		response.InternalError(w, "error getting user data")
+		return
+	}
+
+	response.OK(w, toUserDataResponse(userData, withProfile))
+}
+
+// GetUserDataByExternalID returns information about a user by their external ID,
+// with the extended profile if it is included.
+func (s *serverAPI) GetUserDataByExternalID(w http.ResponseWriter, r *http.Request) {
+	userExternalID, ok := pathID(r, "userExternalId")
+	if !ok {
+		response.InvalidArgumentError(w, "user external id is invalid")
+		return
+	}
+
+	withProfile, ok := includesProfile(r)
+	if !ok {
+		response.InvalidArgumentError(w, "include is invalid")
+		return
+	}
+
+	userData, err := s.userDataByExternalIDUseCase.Execute(r.Context(), userExternalID)
+	if err == nil && withProfile {
+		// The profile is looked up by the internal ID of the user found.
+		userData, err = s.userProfileUseCase.Execute(r.Context(), userData.ID)
+	}
+	if err != nil {
+		if errors.Is(err, usecase.ErrUserNotFound) {
+			response.NotFoundError(w, "user not found")
+			return
+		}
+
+		response.InternalError(w, "error getting user data")
+		return
+	}
+
+	response.OK(w, toUserDataResponse(userData, withProfile))
+}
+
+// GetFollowedUsers returns a list of users that the given user is followed to.
+func (s *serverAPI) GetFollowedUsers(w http.ResponseWriter, r *http.Request) {
+	userID, ok := pathID(r, "userId")
+	if !ok {
+		response.InvalidArgumentError(w, "user id is invalid")
+		return
+	}
+
+	followedUsers, err := s.followedUsersUseCase.Execute(r.Context(), userID)
+	if err != nil {
+		response.InternalError(w, "error getting followed users")
+		return
+	}
+
+	followedUsersResponse := FollowedUsersResponse{
+		Users: make([]FollowedUser, 0, len(followedUsers)),
+	}
+	for _, fu := range followedUsers {
+		followedUsersResponse.Users = append(followedUsersResponse.Users, FollowedUser{
+			FollowLinkID:  fu.ID,
+			UserID:        fu.FollowedUser.ID,
+			FullName:      fu.FollowedUser.FullName,
+			AvatarFileKey: fu.FollowedUser.AvatarFileKey,
+			NumberOfLikes: fu.NumberOfLikes,
+		})
+	}
+
+	response.OK(w, followedUsersResponse)
+}
+
+// FollowUser adds the user with userIdToFollow to the list of followed users with userId.
+func (s *serverAPI) FollowUser(w http.ResponseWriter, r *http.Request) {
+	userID, ok := pathID(r, "userId")
+	if !ok {
+		response.InvalidArgumentError(w, "user id is invalid")
+		return
+	}
+
+	var req FollowUserRequest
+	if err := decodeBody(w, r, &req); err != nil {
+		response.InvalidArgumentError(w, "invalid request body")
+		return
+	}
+
+	if req.UserIDToFollow == emptyValue {
+		response.InvalidArgumentError(w, "user id to follow is empty")
+		return
+	}
+
+	err := s.followUserUseCase.Execute(r.Context(), userID, req.UserIDToFollow)
+	if err != nil {
+		if errors.Is(err, usecase.ErrUserNotFound) {
+			response.NotFoundError(w, "user not found")
+			return
+		}
+		if errors.Is(err, usecase.ErrFollowExists) {
+			response.AlreadyExistsError(w, "follow already exists")
+			return
+		}
+
+		response.InternalError(w, "error following user")
+		return
+	}
+
+	response.OK(w, SuccessResponse{Success: true})
+}
+
+// UnfollowUser removes a user from the follow list.
+func (s *serverAPI) UnfollowUser(w http.ResponseWriter, r *http.Request) {
+	followLinkID, ok := pathID(r, "followLinkId")
+	if !ok {
+		response.InvalidArgumentError(w, "follow link id is invalid")
+		return
+	}
+
+	err := s.unfollowUserUseCase.Execute(r.Context(), followLinkID)
+	if err != nil {
+		if errors.Is(err, usecase.ErrFollowNotFound) {
+			response.NotFoundError(w, "follow not found")
+			return
+		}
+
+		response.InternalError(w, "error unfollowing user")
+		return
+	}
+
+	response.OK(w, SuccessResponse{Success: true})
+}
+
+// GetUserProfile returns the extended profile of a user by their ID.
+func (s *serverAPI) GetUserProfile(w http.ResponseWriter, r *http.Request) {
+	userID, ok := pathID(r, "userId")
+	if !ok {
+		response.InvalidArgumentError(w, "user id is invalid")
+		return
+	}
+
+	userData, err := s.userProfileUseCase.Execute(r.Context(), userID)
+	if err != nil {
+		if errors.Is(err, usecase.ErrUserNotFound) {
+			response.NotFoundError(w, "user not found")
+			return
+		}
+
+		response.InternalError(w, "error getting user profile")
+		return
+	}
+
+	response.OK(w, toProfileResponse(userData))
+}
+
+// UpdateUserProfile replaces the extended profile of a user and returns the updated profile.
+func (s *serverAPI) UpdateUserProfile(w http.ResponseWriter, r *http.Request) {
+	userID, ok := pathID(r, "userId")
+	if !ok {
+		response.InvalidArgumentError(w, "user id is invalid")
+		return
+	}
+
+	var req UpdateProfileRequest
+	if err := decodeBody(w, r, &req); err != nil {
+		response.InvalidArgumentError(w, "invalid request body")
+		return
+	}
+
+	userData, err := s.updateUserProfileUseCase.Execute(r.Context(), userID, dto.UserProfile{
+		Bio:       req.Bio,
+		City:      req.City,
+		Interests: req.Interests,
+	})
+	if err != nil {
+		var invalidProfile *usecase.InvalidProfileError
+		switch {
+		case errors.As(err, &invalidProfile):
+			response.InvalidArgumentError(w, invalidProfile.Error())
+		case errors.Is(err, usecase.ErrUserNotFound):
+			response.NotFoundError(w, "user not found")
+		default:
+			response.InternalError(w, "error updating user profile")
+		}
+
+		return
+	}
+
+	response.OK(w, toProfileResponse(userData))
+}
+
+// GetInterestTags returns the interest tags users can choose from.
+func (s *serverAPI) GetInterestTags(w http.ResponseWriter, r *http.Request) {
+	tags, err := s.interestTagsUseCase.Execute(r.Context())
+	if err != nil {
+		response.InternalError(w, "error getting interest tags")
+		return
+	}
+
+	response.OK(w, InterestTagsResponse{Tags: tags})
+}
+
+// includesProfile returns whether the include query parameter, a comma-separated list, asks for the profile.
+// It returns false if the list has unknown values.
+func includesProfile(r *http.Request) (bool, bool) {
+	include := r.URL.Query().Get("include")
+	if include == "" {
+		return false, true
+	}
+
+	withProfile := false
+	for _, value := range strings.Split(include, ",") {
+		if strings.TrimSpace(value) != includeProfile {
+			return false, false
+		}
+		withProfile = true
+	}
+
+	return withProfile, true
+}
+
+// pathID parses the identifier from the path. It returns false if the identifier is not a non-zero number.
+func pathID(r *http.Request, name string) (int64, bool) {
+	id, err := strconv.ParseInt(r.PathValue(name), 10, 64)
+	if err != nil || id == emptyValue {
+		return 0, false
+	}
+
+	return id, true
+}
+
+// decodeBody decodes the JSON request body into v. Bodies over maxRequestBodySize are rejected.
+func decodeBody(w http.ResponseWriter, r *http.Request, v any) error {
+	return json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRequestBodySize)).Decode(v)
+}
+
+func toUserDataResponse(userData entity.User, withProfile bool) UserDataResponse {
+	gender := genderUnspecified
+	if userData.Gender != nil {
+		switch *userData.Gender {
+		case enum.MALE:
+			gender = genderMale
+		case enum.FEMALE:
+			gender = genderFemale
+		}
+	}
+
+	resp := UserDataResponse{
+		ID:            userData.ID,
+		FullName:      userData.FullName,
+		DateOfBirth:   userData.DateOfBirth,
+		Gender:        gender,
+		AvatarFileKey: userData.AvatarFileKey,
+	}
+	if withProfile {
+		profile := toProfileResponse(userData)
+		resp.Profile = &profile
+	}
+
+	return resp
+}
+
+func toProfileResponse(userData entity.User) ProfileResponse {
+	interests := userData.Interests
+	if interests == nil {
+		interests = make([]string, 0)
+	}
+
+	return ProfileResponse{
+		Bio:       userData.Bio,
+		City:      userData.City,
+		Interests: interests,
+	}
+}
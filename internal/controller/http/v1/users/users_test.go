@@ -0,0 +1,168 @@
+package users
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"love-signal-users/internal/entity"
+	"love-signal-users/internal/usecase"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// fakeUsers is a use-case that returns the user with the ID, or ErrUserNotFound, and records the IDs it is called with.
+type fakeUsers struct {
+	users map[int64]entity.User
+	calls []int64
+}
+
+func (u *fakeUsers) Execute(_ context.Context, id int64) (entity.User, error) {
+	u.calls = append(u.calls, id)
+
+	user, ok := u.users[id]
+	if !ok {
+		return entity.User{}, fmt.Errorf("usecase.fake.Execute: %w", usecase.ErrUserNotFound)
+	}
+
+	return user, nil
+}
+
+func Test_GetUserDataIncludesProfile(t *testing.T) {
+	bio, city := "Hello", "Paris"
+	userData := &fakeUsers{users: map[int64]entity.User{1: {ID: 1, ExternalID: 101, FullName: "Ann"}}}
+	userDataByExternalID := &fakeUsers{users: map[int64]entity.User{101: {ID: 1, ExternalID: 101, FullName: "Ann"}}}
+	userProfile := &fakeUsers{users: map[int64]entity.User{
+		1: {ID: 1, ExternalID: 101, FullName: "Ann", Bio: &bio, City: &city, Interests: []string{"art", "music"}},
+		2: {ID: 2, ExternalID: 102, FullName: "Bob"},
+	}}
+
+	mux := http.NewServeMux()
+	RegisterUsersRoutes(mux, "/v1", userData, userDataByExternalID, nil, nil, nil, userProfile, nil, nil)
+
+	tests := []struct {
+		name         string
+		target       string
+		status       int
+		profile      *ProfileResponse
+		profileCalls []int64
+	}{
+		{
+			name:   "without include",
+			target: "/v1/users/1",
+			status: http.StatusOK,
+		},
+		{
+			name:         "with profile",
+			target:       "/v1/users/1?include=profile",
+			status:       http.StatusOK,
+			profile:      &ProfileResponse{Bio: &bio, City: &city, Interests: []string{"art", "music"}},
+			profileCalls: []int64{1},
+		},
+		{
+			name:         "with empty profile",
+			target:       "/v1/users/2?include=profile",
+			status:       http.StatusOK,
+			profile:      &ProfileResponse{Interests: []string{}},
+			profileCalls: []int64{2},
+		},
+		{
+			name:         "by external ID with profile",
+			target:       "/v1/external-users/101?include=%20profile,profile",
+			status:       http.StatusOK,
+			profile:      &ProfileResponse{Bio: &bio, City: &city, Interests: []string{"art", "music"}},
+			profileCalls: []int64{1},
+		},
+		{
+			name:   "unknown include",
+			target: "/v1/users/1?include=profile,follows",
+			status: http.StatusBadRequest,
+		},
+		{
+			name:         "user not found",
+			target:       "/v1/users/3?include=profile",
+			status:       http.StatusNotFound,
+			profileCalls: []int64{3},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userProfile.calls = nil
+
+			rec := httptest.NewRecorder()
+			mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.target, nil))
+
+			if rec.Code != tt.status {
+				t.Fatalf("expected status %d, got: %d, body: %s", tt.status, rec.Code, rec.Body.String())
+			}
+			if !slices.Equal(userProfile.calls, tt.profileCalls) {
+				t.Errorf("expected profile use-case calls %v, got: %v", tt.profileCalls, userProfile.calls)
+			}
+			if tt.status != http.StatusOK {
+				return
+			}
+
+			var body map[string]json.RawMessage
+			if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			rawProfile, ok := body["profile"]
+			if tt.profile == nil {
+				if ok {
+					t.Errorf("expected no profile, got: %s", rawProfile)
+				}
+
+				return
+			}
+
+			expected, err := json.Marshal(tt.profile)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(rawProfile) != string(expected) {
+				t.Errorf("expected profile %s, got: %s", expected, rawProfile)
+			}
+		})
+	}
+}
+
+// fakeFollow is a follow use-case that returns the error.
+type fakeFollow struct {
+	err error
+}
+
+func (f *fakeFollow) Execute(context.Context, int64, int64) error {
+	return f.err
+}
+
+func Test_FollowUserMapsErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		status int
+	}{
+		{name: "followed", status: http.StatusOK},
+		{name: "user not found", err: usecase.ErrUserNotFound, status: http.StatusNotFound},
+		{name: "follow exists", err: usecase.ErrFollowExists, status: http.StatusConflict},
+		{name: "storage error", err: errors.New("storage is down"), status: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux := http.NewServeMux()
+			RegisterUsersRoutes(mux, "/v1", nil, nil, nil, &fakeFollow{err: tt.err}, nil, nil, nil, nil)
+
+			rec := httptest.NewRecorder()
+			mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/v1/users/1/followed",
+				strings.NewReader(`{"userIdToFollow":2}`)))
+
+			if rec.Code != tt.status {
+				t.Errorf("expected status %d, got: %d, body: %s", tt.status, rec.Code, rec.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,33 @@
+package dto
+
+import "time"
+
+// ArchivedUser is a DTO with all the stored data of a user, who may be deleted.
+type ArchivedUser struct {
+	User
+	Deleted   bool
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// ArchivedFollow is a DTO with a follow link of an archived user, with its users identified by external ID.
+type ArchivedFollow struct {
+	ID                  int64
+	FollowingExternalID int64
+	FollowedExternalID  int64
+	NumberOfLikes       uint32
+	CreatedAt           time.Time
+	UpdatedAt           time.Time
+}
+
+// HistoryRecord is a DTO with an audit record of a call involving a user.
+type HistoryRecord struct {
+	Time      time.Time
+	Action    string
+	Actor     string
+	Caller    string
+	Target    string
+	Result    string
+	Error     string
+	RequestID string
+}
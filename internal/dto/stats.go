@@ -0,0 +1,10 @@
+package dto
+
+// Stats is a DTO with the numbers of users, follows and likes and the size of the storage.
+type Stats struct {
+	Users        int64
+	DeletedUsers int64
+	Follows      int64
+	Likes        int64
+	SizeBytes    int64
+}
@@ -5,12 +5,22 @@ import (
 	"time"
 )
 
-// User is a DTO with user data.
+// User is a DTO with user data. Interests are nil if they were not loaded.
 type User struct {
 	ID            int64
 	ExternalID    int64
-	FullName      string
+	FullName      string `log:"sensitive"`
 	Gender        *enum.Gender
-	DateOfBirth   *time.Time
+	DateOfBirth   *time.Time `log:"sensitive"`
 	AvatarFileKey *string
+	Bio           *string `log:"sensitive"`
+	City          *string `log:"sensitive"`
+	Interests     []string
+}
+
+// UserProfile is a DTO with the extended profile of a user to set. Empty fields and interests are cleared.
+type UserProfile struct {
+	Bio       *string `log:"sensitive"`
+	City      *string `log:"sensitive"`
+	Interests []string
 }
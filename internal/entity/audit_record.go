@@ -0,0 +1,48 @@
+package entity
+
+import (
+	"fmt"
+	"love-signal-users/internal/enum"
+	"time"
+)
+
+// AuditRecord is the record of a state-changing call.
+type AuditRecord struct {
+	Action     enum.AuditAction
+	Actor      string
+	Target     string
+	Err        error
+	OccurredAt time.Time
+}
+
+// NewAuditRecord returns new audit record of the action of the actor on the target with the result error.
+// The actor and the target are references like UserRef.
+func NewAuditRecord(action enum.AuditAction, actor, target string, err error) AuditRecord {
+	return AuditRecord{
+		Action:     action,
+		Actor:      actor,
+		Target:     target,
+		Err:        err,
+		OccurredAt: time.Now(),
+	}
+}
+
+// UserRef returns the reference to the user for audit records.
+func UserRef(id int64) string {
+	return fmt.Sprintf("user:%d", id)
+}
+
+// FollowRef returns the reference to the follow link for audit records.
+func FollowRef(id int64) string {
+	return fmt.Sprintf("follow:%d", id)
+}
+
+// ExternalUserRef returns the reference to the user by their external ID for audit records.
+func ExternalUserRef(externalID int64) string {
+	return fmt.Sprintf("external_user:%d", externalID)
+}
+
+// OperatorRef returns the reference to the operator, the caller of an admin call, for audit records.
+func OperatorRef(caller string) string {
+	return "operator:" + caller
+}
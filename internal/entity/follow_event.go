@@ -0,0 +1,26 @@
+package entity
+
+import (
+	"love-signal-users/internal/enum"
+	"time"
+)
+
+// FollowEvent is the follow graph event entity.
+type FollowEvent struct {
+	Type         enum.FollowEventType
+	UserID       int64
+	ActorUserID  int64
+	FollowLinkID int64
+	OccurredAt   time.Time
+}
+
+// NewFollowEvent returns new follow event entity for the user caused by the actor user.
+func NewFollowEvent(eventType enum.FollowEventType, userID, actorUserID, followLinkID int64) FollowEvent {
+	return FollowEvent{
+		Type:         eventType,
+		UserID:       userID,
+		ActorUserID:  actorUserID,
+		FollowLinkID: followLinkID,
+		OccurredAt:   time.Now(),
+	}
+}
@@ -0,0 +1,23 @@
+package entity
+
+import "love-signal-users/internal/dto"
+
+// Stats is the numbers of users, follows and likes and the size of the storage.
+type Stats struct {
+	Users        int64
+	DeletedUsers int64
+	Follows      int64
+	Likes        int64
+	SizeBytes    int64
+}
+
+// NewStats returns new stats entity.
+func NewStats(data dto.Stats) Stats {
+	return Stats{
+		Users:        data.Users,
+		DeletedUsers: data.DeletedUsers,
+		Follows:      data.Follows,
+		Likes:        data.Likes,
+		SizeBytes:    data.SizeBytes,
+	}
+}
@@ -6,14 +6,24 @@ import (
 	"time"
 )
 
-// User is the user entity.
+// Limits of the extended profile of a user. Lengths are in characters.
+const (
+	MaxBioLength  = 500
+	MaxCityLength = 100
+	MaxInterests  = 10
+)
+
+// User is the user entity. Interests are nil if they were not loaded.
 type User struct {
 	ID            int64
 	ExternalID    int64
-	FullName      string
+	FullName      string `log:"sensitive"`
 	Gender        *enum.Gender
-	DateOfBirth   *time.Time
+	DateOfBirth   *time.Time `log:"sensitive"`
 	AvatarFileKey *string
+	Bio           *string `log:"sensitive"`
+	City          *string `log:"sensitive"`
+	Interests     []string
 
 	dataStatus enum.DataStatus
 }
@@ -27,9 +37,19 @@ func NewUser(data dto.User) User {
 		Gender:        data.Gender,
 		DateOfBirth:   data.DateOfBirth,
 		AvatarFileKey: data.AvatarFileKey,
+		Bio:           data.Bio,
+		City:          data.City,
+		Interests:     data.Interests,
 	}
 }
 
+// SetProfile replaces the extended profile of the user.
+func (u *User) SetProfile(profile dto.UserProfile) {
+	u.Bio = profile.Bio
+	u.City = profile.City
+	u.Interests = profile.Interests
+}
+
 func (u *User) SetToCreate() {
 	u.dataStatus = enum.ToCreate
 }
@@ -0,0 +1,85 @@
+package entity
+
+import (
+	"love-signal-users/internal/dto"
+	"love-signal-users/internal/enum"
+	"time"
+)
+
+// UserArchive is everything stored about a user, for answering their data access request.
+type UserArchive struct {
+	ExternalID    int64
+	FullName      string `log:"sensitive"`
+	Gender        *enum.Gender
+	DateOfBirth   *time.Time `log:"sensitive"`
+	AvatarFileKey *string
+	Bio           *string `log:"sensitive"`
+	City          *string `log:"sensitive"`
+	Interests     []string
+	Deleted       bool
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+
+	// Following are the links to the users the user follows.
+	Following []ArchivedFollow
+	// Followers are the links from the users that follow the user.
+	Followers []ArchivedFollow
+	// History is the audit records of the calls involving the user, oldest first.
+	History []dto.HistoryRecord
+
+	GeneratedAt time.Time
+}
+
+// ArchivedFollow is a follow link of an archived user with the other user.
+type ArchivedFollow struct {
+	UserExternalID int64
+	NumberOfLikes  uint32
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+// NewUserArchive returns new user archive entity of the user, their follow links in both directions and history.
+func NewUserArchive(
+	user dto.ArchivedUser,
+	follows []dto.ArchivedFollow,
+	history []dto.HistoryRecord,
+	generatedAt time.Time,
+) UserArchive {
+	archive := UserArchive{
+		ExternalID:    user.ExternalID,
+		FullName:      user.FullName,
+		Gender:        user.Gender,
+		DateOfBirth:   user.DateOfBirth,
+		AvatarFileKey: user.AvatarFileKey,
+		Bio:           user.Bio,
+		City:          user.City,
+		Interests:     user.Interests,
+		Deleted:       user.Deleted,
+		CreatedAt:     user.CreatedAt,
+		UpdatedAt:     user.UpdatedAt,
+		Following:     make([]ArchivedFollow, 0),
+		Followers:     make([]ArchivedFollow, 0),
+		History:       history,
+		GeneratedAt:   generatedAt,
+	}
+
+	for _, follow := range follows {
+		if follow.FollowingExternalID == user.ExternalID {
+			archive.Following = append(archive.Following, ArchivedFollow{
+				UserExternalID: follow.FollowedExternalID,
+				NumberOfLikes:  follow.NumberOfLikes,
+				CreatedAt:      follow.CreatedAt,
+				UpdatedAt:      follow.UpdatedAt,
+			})
+		} else {
+			archive.Followers = append(archive.Followers, ArchivedFollow{
+				UserExternalID: follow.FollowingExternalID,
+				NumberOfLikes:  follow.NumberOfLikes,
+				CreatedAt:      follow.CreatedAt,
+				UpdatedAt:      follow.UpdatedAt,
+			})
+		}
+	}
+
+	return archive
+}
@@ -0,0 +1,14 @@
+package enum
+
+// AuditAction is type for audit action enum. Actions are written to the audit log by their names.
+type AuditAction string
+
+// AuditAction enum.
+const (
+	AuditFollow        AuditAction = "follow"
+	AuditUnfollow      AuditAction = "unfollow"
+	AuditDeactivate    AuditAction = "deactivate"
+	AuditRestore       AuditAction = "restore"
+	AuditExportData    AuditAction = "export_data"
+	AuditUpdateProfile AuditAction = "update_profile"
+)
@@ -0,0 +1,13 @@
+package enum
+
+// FollowEventType is type for follow event type enum.
+type FollowEventType int16
+
+// FollowEventType enum.
+const (
+	NewFollower FollowEventType = 1
+	Unfollowed  FollowEventType = 2
+	// LikeReceived is reserved for like operations. It is never published, as the service has no like operations.
+	LikeReceived FollowEventType = 3
+	Match        FollowEventType = 4
+)
@@ -0,0 +1,83 @@
+package audit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"love-signal-users/internal/dto"
+	"love-signal-users/pkg/audit"
+	"love-signal-users/pkg/tracing"
+	"os"
+)
+
+// History reads the records of the audit log file about users.
+type History struct {
+	path string
+}
+
+// NewHistory returns new history of the audit log file. With an empty path, the audit log is disabled
+// and there is no history.
+func NewHistory(path string) *History {
+	return &History{path: path}
+}
+
+// Records returns the records with the actor or the target among the refs, oldest first.
+// The whole audit log is read, as it has no index.
+func (h *History) Records(ctx context.Context, refs []string) (_ []dto.HistoryRecord, err error) {
+	const op = "audit.History.Records"
+
+	ctx, span := tracing.Start(ctx, op)
+	defer tracing.Finish(span, &err)
+
+	records := make([]dto.HistoryRecord, 0)
+	if h.path == "" {
+		return records, nil
+	}
+
+	file, err := os.Open(h.path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return records, nil
+		}
+
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	wanted := make(map[string]struct{}, len(refs))
+	for _, ref := range refs {
+		wanted[ref] = struct{}{}
+	}
+
+	err = audit.Read(file, func(rec audit.Record) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		_, actor := wanted[rec.Actor]
+		_, target := wanted[rec.Target]
+		if !actor && !target {
+			return nil
+		}
+
+		records = append(records, dto.HistoryRecord{
+			Time:      rec.Time,
+			Action:    rec.Action,
+			Actor:     rec.Actor,
+			Caller:    rec.Caller,
+			Target:    rec.Target,
+			Result:    rec.Result,
+			Error:     rec.Error,
+			RequestID: rec.RequestID,
+		})
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return records, nil
+}
@@ -0,0 +1,59 @@
+package audit
+
+import (
+	"context"
+	"log/slog"
+	"love-signal-users/internal/entity"
+	"love-signal-users/pkg/audit"
+	"love-signal-users/pkg/caller"
+	"love-signal-users/pkg/logger"
+	"love-signal-users/pkg/logger/sl"
+	"love-signal-users/pkg/requestid"
+)
+
+// Recorder writes audit records of the use-cases to the audit log,
+// with the caller and the request ID from the request context.
+type Recorder struct {
+	log      *slog.Logger
+	auditLog *audit.Log
+}
+
+// NewRecorder returns new audit recorder. If the audit log is nil, records are discarded.
+func NewRecorder(log *slog.Logger, auditLog *audit.Log) *Recorder {
+	return &Recorder{
+		log:      log,
+		auditLog: auditLog,
+	}
+}
+
+// Audit writes the record to the audit log. A failed write does not fail the call, it is logged as an error.
+func (r *Recorder) Audit(ctx context.Context, record entity.AuditRecord) {
+	const op = "audit.Recorder.Audit"
+
+	if r.auditLog == nil {
+		return
+	}
+
+	rec := audit.Record{
+		Time:      record.OccurredAt.UTC(),
+		Action:    string(record.Action),
+		Actor:     record.Actor,
+		Caller:    caller.FromContext(ctx),
+		Target:    record.Target,
+		Result:    audit.ResultSuccess,
+		RequestID: requestid.FromContext(ctx),
+	}
+	if record.Err != nil {
+		rec.Result = audit.ResultFailure
+		rec.Error = record.Err.Error()
+	}
+
+	if err := r.auditLog.Write(rec); err != nil {
+		logger.FromContext(ctx, r.log).Error(
+			"error writing audit record",
+			slog.String("op", op),
+			slog.String("action", rec.Action),
+			sl.Err(err),
+		)
+	}
+}
@@ -1,6 +1,7 @@
 package converter
 
 import (
+	"github.com/guregu/null/v6"
 	"love-signal-users/internal/dto"
 	"love-signal-users/internal/entity"
 	"love-signal-users/internal/enum"
@@ -16,9 +17,18 @@ func ToUserDTO(user models.User) dto.User {
 		Gender:        enum.GenderFromNullInt16(user.Gender),
 		DateOfBirth:   user.DateOfBirth.Ptr(),
 		AvatarFileKey: user.AvatarFileKey.Ptr(),
+		Bio:           user.Bio.Ptr(),
+		City:          user.City.Ptr(),
 	}
 }
 
+func ToUserWithInterestsDTO(user models.User, interests []string) dto.User {
+	userDTO := ToUserDTO(user)
+	userDTO.Interests = interests
+
+	return userDTO
+}
+
 func ToFollowDTO(follow models.Follow, users []models.User) (dto.Follow, error) {
 	followingUser, err := findUserByID(users, follow.FollowingUserID)
 	if err != nil {
@@ -38,6 +48,36 @@ func ToFollowDTO(follow models.Follow, users []models.User) (dto.Follow, error)
 	}, nil
 }
 
+func ToStatsDTO(stats models.DatabaseStats) dto.Stats {
+	return dto.Stats{
+		Users:        stats.Users,
+		DeletedUsers: stats.DeletedUsers,
+		Follows:      stats.Follows,
+		Likes:        stats.Likes,
+		SizeBytes:    stats.SizeBytes,
+	}
+}
+
+func ToArchivedUserDTO(user models.User) dto.ArchivedUser {
+	return dto.ArchivedUser{
+		User:      ToUserDTO(user),
+		Deleted:   user.Deleted,
+		CreatedAt: user.CreatedAt,
+		UpdatedAt: user.UpdatedAt,
+	}
+}
+
+func ToArchivedFollowDTO(follow models.ExternalFollow) dto.ArchivedFollow {
+	return dto.ArchivedFollow{
+		ID:                  follow.ID,
+		FollowingExternalID: follow.FollowingExternalID,
+		FollowedExternalID:  follow.FollowedExternalID,
+		NumberOfLikes:       follow.NumberOfLikes,
+		CreatedAt:           follow.CreatedAt,
+		UpdatedAt:           follow.UpdatedAt,
+	}
+}
+
 func ToFollowStorage(follow *entity.Follow, setters ...models.FollowOption) models.Follow {
 	followStorage := models.Follow{
 		ID:              follow.ID,
@@ -53,6 +93,21 @@ func ToFollowStorage(follow *entity.Follow, setters ...models.FollowOption) mode
 	return followStorage
 }
 
+func ToUserProfileStorage(user entity.User, setters ...models.UserProfileOption) models.UserProfile {
+	profileStorage := models.UserProfile{
+		UserID:    user.ID,
+		Bio:       null.StringFromPtr(user.Bio),
+		City:      null.StringFromPtr(user.City),
+		Interests: user.Interests,
+	}
+
+	for _, setter := range setters {
+		setter(&profileStorage)
+	}
+
+	return profileStorage
+}
+
 func findUserByID(users []models.User, id int64) (models.User, error) {
 	for _, user := range users {
 		if user.ID == id {
@@ -10,7 +10,9 @@ import (
 	"love-signal-users/internal/infrastructure"
 	"love-signal-users/internal/infrastructure/converter"
 	"love-signal-users/internal/infrastructure/storage/models"
+	"love-signal-users/pkg/logger"
 	"love-signal-users/pkg/logger/sl"
+	"love-signal-users/pkg/tracing"
 )
 
 const emptyID = 0
@@ -20,9 +22,18 @@ type Storage interface {
 	User(ctx context.Context, id int64) (models.User, error)
 	UserByExternalID(ctx context.Context, externalID int64) (models.User, error)
 	FollowsByUserID(ctx context.Context, userID int64) ([]models.Follow, error)
+	Follow(ctx context.Context, followLinkID int64) (models.Follow, error)
+	FollowExists(ctx context.Context, followingUserID, followedUserID int64) (bool, error)
 	CreateFollow(ctx context.Context, follow models.Follow) (int64, error)
 	UpdateFollow(ctx context.Context, follow models.Follow) error
 	RemoveFollow(ctx context.Context, id int64) error
+	SetUserDeleted(ctx context.Context, userID int64, deleted bool) error
+	DatabaseStats(ctx context.Context) (models.DatabaseStats, error)
+	ArchivedUser(ctx context.Context, externalID int64) (models.User, error)
+	ArchivedFollows(ctx context.Context, userID int64) ([]models.ExternalFollow, error)
+	UserInterests(ctx context.Context, userID int64) ([]string, error)
+	InterestTags(ctx context.Context) ([]string, error)
+	UpdateUserProfile(ctx context.Context, profile models.UserProfile) error
 }
 
 type Users struct {
@@ -37,10 +48,13 @@ func NewUsersRepository(log *slog.Logger, storage Storage) *Users {
 	}
 }
 
-func (u *Users) User(ctx context.Context, id int64) (dto.User, error) {
+func (u *Users) User(ctx context.Context, id int64) (_ dto.User, err error) {
 	const op = "repository.users.User"
 
-	log := u.log.With(
+	ctx, span := tracing.Start(ctx, op, slog.Int64("user ID", id))
+	defer tracing.Finish(span, &err)
+
+	log := logger.FromContext(ctx, u.log).With(
 		slog.String("op", op),
 		slog.Int64("user ID", id),
 	)
@@ -61,10 +75,13 @@ func (u *Users) User(ctx context.Context, id int64) (dto.User, error) {
 	return userDTO, nil
 }
 
-func (u *Users) UserByExternalID(ctx context.Context, externalID int64) (dto.User, error) {
+func (u *Users) UserByExternalID(ctx context.Context, externalID int64) (_ dto.User, err error) {
 	const op = "repository.users.UserByExternalID"
 
-	log := u.log.With(
+	ctx, span := tracing.Start(ctx, op, slog.Int64("user external ID", externalID))
+	defer tracing.Finish(span, &err)
+
+	log := logger.FromContext(ctx, u.log).With(
 		slog.String("op", op),
 		slog.Int64("user external ID", externalID),
 	)
@@ -85,10 +102,13 @@ func (u *Users) UserByExternalID(ctx context.Context, externalID int64) (dto.Use
 	return userDTO, nil
 }
 
-func (u *Users) Follows(ctx context.Context, userID int64) ([]dto.Follow, error) {
+func (u *Users) Follows(ctx context.Context, userID int64) (_ []dto.Follow, err error) {
 	const op = "repository.users.Follows"
 
-	log := u.log.With(
+	ctx, span := tracing.Start(ctx, op, slog.Int64("user ID", userID))
+	defer tracing.Finish(span, &err)
+
+	log := logger.FromContext(ctx, u.log).With(
 		slog.String("op", op),
 		slog.Int64("user ID", userID),
 	)
@@ -126,11 +146,81 @@ func (u *Users) Follows(ctx context.Context, userID int64) ([]dto.Follow, error)
 	return followsDTO, nil
 }
 
-func (u *Users) SaveFollow(ctx context.Context, follow *entity.Follow) error {
+func (u *Users) Follow(ctx context.Context, followLinkID int64) (_ dto.Follow, err error) {
+	const op = "repository.users.Follow"
+
+	ctx, span := tracing.Start(ctx, op, slog.Int64("follow link ID", followLinkID))
+	defer tracing.Finish(span, &err)
+
+	log := logger.FromContext(ctx, u.log).With(
+		slog.String("op", op),
+		slog.Int64("follow link ID", followLinkID),
+	)
+
+	follow, err := u.storage.Follow(ctx, followLinkID)
+	if err != nil {
+		if errors.Is(err, infrastructure.ErrEntityNotFound) {
+			log.Warn("follow not found", sl.Err(err))
+		} else {
+			log.Error("error getting follow", sl.Err(err))
+		}
+
+		return dto.Follow{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	users, err := u.storage.Users(ctx, []int64{follow.FollowingUserID, follow.FollowedUserID})
+	if err != nil {
+		log.Error("error getting users", sl.Err(err))
+
+		return dto.Follow{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	followDTO, err := converter.ToFollowDTO(follow, users)
+	if err != nil {
+		return dto.Follow{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return followDTO, nil
+}
+
+func (u *Users) FollowExists(ctx context.Context, followingUserID, followedUserID int64) (_ bool, err error) {
+	const op = "repository.users.FollowExists"
+
+	ctx, span := tracing.Start(ctx, op,
+		slog.Int64("following user ID", followingUserID),
+		slog.Int64("followed user ID", followedUserID),
+	)
+	defer tracing.Finish(span, &err)
+
+	log := logger.FromContext(ctx, u.log).With(
+		slog.String("op", op),
+		slog.Int64("following user ID", followingUserID),
+		slog.Int64("followed user ID", followedUserID),
+	)
+
+	exists, err := u.storage.FollowExists(ctx, followingUserID, followedUserID)
+	if err != nil {
+		log.Error("error checking follow existence", sl.Err(err))
+
+		return false, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return exists, nil
+}
+
+func (u *Users) SaveFollow(ctx context.Context, follow *entity.Follow) (err error) {
 	const op = "repository.users.SaveFollow"
 
-	log := u.log.With(
+	ctx, span := tracing.Start(ctx, op,
+		slog.Int64("following user ID", follow.FollowingUser.ID),
+		slog.Int64("followed user ID", follow.FollowedUser.ID),
+	)
+	defer tracing.Finish(span, &err)
+
+	log := logger.FromContext(ctx, u.log).With(
 		slog.String("op", op),
+		slog.Int64("following user ID", follow.FollowingUser.ID),
+		slog.Int64("followed user ID", follow.FollowedUser.ID),
 	)
 
 	if follow.IsToCreate() {
@@ -141,6 +231,10 @@ func (u *Users) SaveFollow(ctx context.Context, follow *entity.Follow) error {
 		}
 	}
 
+	// The ID of a created follow is known only after the insert.
+	tracing.SetAttributes(span, slog.Int64("follow link ID", follow.ID))
+	log = log.With(slog.Int64("follow link ID", follow.ID))
+
 	if follow.IsToUpdate() {
 		if err := u.updateFollow(ctx, follow); err != nil {
 			log.Error("error updating follow", sl.Err(err))
@@ -160,6 +254,203 @@ func (u *Users) SaveFollow(ctx context.Context, follow *entity.Follow) error {
 	return nil
 }
 
+func (u *Users) SetUserDeleted(ctx context.Context, id int64, deleted bool) (err error) {
+	const op = "repository.users.SetUserDeleted"
+
+	ctx, span := tracing.Start(ctx, op, slog.Int64("user ID", id), slog.Bool("deleted", deleted))
+	defer tracing.Finish(span, &err)
+
+	log := logger.FromContext(ctx, u.log).With(
+		slog.String("op", op),
+		slog.Int64("user ID", id),
+		slog.Bool("deleted", deleted),
+	)
+
+	if err = u.storage.SetUserDeleted(ctx, id, deleted); err != nil {
+		if errors.Is(err, infrastructure.ErrEntityNotFound) {
+			log.Warn("user not found", sl.Err(err))
+		} else {
+			log.Error("error setting user deleted", sl.Err(err))
+		}
+
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (u *Users) Stats(ctx context.Context) (_ dto.Stats, err error) {
+	const op = "repository.users.Stats"
+
+	ctx, span := tracing.Start(ctx, op)
+	defer tracing.Finish(span, &err)
+
+	log := logger.FromContext(ctx, u.log).With(
+		slog.String("op", op),
+	)
+
+	stats, err := u.storage.DatabaseStats(ctx)
+	if err != nil {
+		log.Error("error getting database stats", sl.Err(err))
+
+		return dto.Stats{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return converter.ToStatsDTO(stats), nil
+}
+
+func (u *Users) ArchivedUser(ctx context.Context, externalID int64) (_ dto.ArchivedUser, err error) {
+	const op = "repository.users.ArchivedUser"
+
+	ctx, span := tracing.Start(ctx, op, slog.Int64("user external ID", externalID))
+	defer tracing.Finish(span, &err)
+
+	log := logger.FromContext(ctx, u.log).With(
+		slog.String("op", op),
+		slog.Int64("user external ID", externalID),
+	)
+
+	user, err := u.storage.ArchivedUser(ctx, externalID)
+	if err != nil {
+		if errors.Is(err, infrastructure.ErrEntityNotFound) {
+			log.Warn("user not found", sl.Err(err))
+		} else {
+			log.Error("error getting archived user", sl.Err(err))
+		}
+
+		return dto.ArchivedUser{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return converter.ToArchivedUserDTO(user), nil
+}
+
+func (u *Users) ArchivedFollows(ctx context.Context, userID int64) (_ []dto.ArchivedFollow, err error) {
+	const op = "repository.users.ArchivedFollows"
+
+	ctx, span := tracing.Start(ctx, op, slog.Int64("user ID", userID))
+	defer tracing.Finish(span, &err)
+
+	log := logger.FromContext(ctx, u.log).With(
+		slog.String("op", op),
+		slog.Int64("user ID", userID),
+	)
+
+	follows, err := u.storage.ArchivedFollows(ctx, userID)
+	if err != nil {
+		log.Error("error getting archived follows", sl.Err(err))
+
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	result := make([]dto.ArchivedFollow, 0, len(follows))
+	for _, follow := range follows {
+		result = append(result, converter.ToArchivedFollowDTO(follow))
+	}
+
+	return result, nil
+}
+
+func (u *Users) UserWithInterests(ctx context.Context, id int64) (_ dto.User, err error) {
+	const op = "repository.users.UserWithInterests"
+
+	ctx, span := tracing.Start(ctx, op, slog.Int64("user ID", id))
+	defer tracing.Finish(span, &err)
+
+	log := logger.FromContext(ctx, u.log).With(
+		slog.String("op", op),
+		slog.Int64("user ID", id),
+	)
+
+	user, err := u.storage.User(ctx, id)
+	if err != nil {
+		if errors.Is(err, infrastructure.ErrEntityNotFound) {
+			log.Warn("user not found", sl.Err(err))
+		} else {
+			log.Error("error getting user", sl.Err(err))
+		}
+
+		return dto.User{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	interests, err := u.storage.UserInterests(ctx, id)
+	if err != nil {
+		log.Error("error getting user interests", sl.Err(err))
+
+		return dto.User{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return converter.ToUserWithInterestsDTO(user, interests), nil
+}
+
+func (u *Users) UserInterests(ctx context.Context, userID int64) (_ []string, err error) {
+	const op = "repository.users.UserInterests"
+
+	ctx, span := tracing.Start(ctx, op, slog.Int64("user ID", userID))
+	defer tracing.Finish(span, &err)
+
+	log := logger.FromContext(ctx, u.log).With(
+		slog.String("op", op),
+		slog.Int64("user ID", userID),
+	)
+
+	interests, err := u.storage.UserInterests(ctx, userID)
+	if err != nil {
+		log.Error("error getting user interests", sl.Err(err))
+
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return interests, nil
+}
+
+func (u *Users) InterestTags(ctx context.Context) (_ []string, err error) {
+	const op = "repository.users.InterestTags"
+
+	ctx, span := tracing.Start(ctx, op)
+	defer tracing.Finish(span, &err)
+
+	log := logger.FromContext(ctx, u.log).With(
+		slog.String("op", op),
+	)
+
+	tags, err := u.storage.InterestTags(ctx)
+	if err != nil {
+		log.Error("error getting interest tags", sl.Err(err))
+
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return tags, nil
+}
+
+func (u *Users) UpdateUserProfile(ctx context.Context, user entity.User) (err error) {
+	const op = "repository.users.UpdateUserProfile"
+
+	ctx, span := tracing.Start(ctx, op, slog.Int64("user ID", user.ID))
+	defer tracing.Finish(span, &err)
+
+	log := logger.FromContext(ctx, u.log).With(
+		slog.String("op", op),
+		slog.Int64("user ID", user.ID),
+	)
+
+	if user.ID == emptyID {
+		return fmt.Errorf("%s: %w", op, infrastructure.ErrRequireIDToUpdate)
+	}
+
+	if err = u.storage.UpdateUserProfile(ctx, converter.ToUserProfileStorage(user, models.UserProfileUpdated())); err != nil {
+		if errors.Is(err, infrastructure.ErrEntityNotFound) {
+			log.Warn("user not found", sl.Err(err))
+		} else {
+			log.Error("error updating user profile", sl.Err(err))
+		}
+
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
 func (u *Users) createFollow(ctx context.Context, follow *entity.Follow) error {
 	followStorageModel := converter.ToFollowStorage(follow, models.FollowCreated())
 
@@ -0,0 +1,10 @@
+package models
+
+// DatabaseStats is the numbers of rows and the size of the database.
+type DatabaseStats struct {
+	Users        int64
+	DeletedUsers int64
+	Follows      int64
+	Likes        int64
+	SizeBytes    int64
+}
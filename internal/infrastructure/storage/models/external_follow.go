@@ -0,0 +1,14 @@
+package models
+
+import "time"
+
+// ExternalFollow is data for follow link in storage with its users identified by external IDs,
+// which are the same in all environments.
+type ExternalFollow struct {
+	ID                  int64
+	FollowingExternalID int64
+	FollowedExternalID  int64
+	NumberOfLikes       uint32
+	CreatedAt           time.Time
+	UpdatedAt           time.Time
+}
@@ -9,10 +9,12 @@ import (
 type User struct {
 	ID            int64
 	ExternalID    int64
-	FullName      string
-	DateOfBirth   null.Time
+	FullName      string    `log:"sensitive"`
+	DateOfBirth   null.Time `log:"sensitive"`
 	Gender        null.Int16
 	AvatarFileKey null.String
+	Bio           null.String `log:"sensitive"`
+	City          null.String `log:"sensitive"`
 	Deleted       bool
 	CreatedAt     time.Time
 	UpdatedAt     time.Time
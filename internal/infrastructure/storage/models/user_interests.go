@@ -0,0 +1,7 @@
+package models
+
+// UserWithInterests is data for user in storage with the names of the interest tags of the user.
+type UserWithInterests struct {
+	User      User
+	Interests []string
+}
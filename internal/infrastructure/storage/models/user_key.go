@@ -0,0 +1,7 @@
+package models
+
+// UserKey is the internal and external IDs of a user in storage.
+type UserKey struct {
+	ID         int64
+	ExternalID int64
+}
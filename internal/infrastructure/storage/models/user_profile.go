@@ -0,0 +1,24 @@
+package models
+
+import (
+	"github.com/guregu/null/v6"
+	"time"
+)
+
+// UserProfile is data for the extended profile of a user in storage: the profile fields of the users table
+// and the names of the interest tags of the user.
+type UserProfile struct {
+	UserID    int64
+	Bio       null.String `log:"sensitive"`
+	City      null.String `log:"sensitive"`
+	Interests []string
+	UpdatedAt time.Time
+}
+
+type UserProfileOption func(*UserProfile)
+
+func UserProfileUpdated() UserProfileOption {
+	return func(p *UserProfile) {
+		p.UpdatedAt = time.Now()
+	}
+}
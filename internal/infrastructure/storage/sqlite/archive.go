@@ -0,0 +1,92 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"love-signal-users/internal/infrastructure"
+	"love-signal-users/internal/infrastructure/storage/models"
+)
+
+// ArchivedUser returns a user by their external ID from storage, including a deleted user.
+func (s *Storage) ArchivedUser(ctx context.Context, externalID int64) (_ models.User, err error) {
+	const op = "sqlite.ArchivedUser"
+
+	ctx, finish := s.startOperation(ctx, op, slog.Int64("user external ID", externalID))
+	defer func() { finish(err) }()
+
+	var user models.User
+	err = s.db.QueryRowContext(ctx,
+		`select id, external_id, full_name, date_of_birth, gender, avatar_file_key, bio, city, deleted,
+			created_at, updated_at
+		from users
+		where external_id = ?;`, externalID,
+	).Scan(
+		&user.ID,
+		&user.ExternalID,
+		&user.FullName,
+		&user.DateOfBirth,
+		&user.Gender,
+		&user.AvatarFileKey,
+		&user.Bio,
+		&user.City,
+		&user.Deleted,
+		&user.CreatedAt,
+		&user.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.User{}, fmt.Errorf("%s: %w", op, infrastructure.ErrEntityNotFound)
+		}
+
+		return models.User{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return user, nil
+}
+
+// ArchivedFollows returns the follow links of the user in both directions from storage,
+// with the external IDs of their users, in order of ID. Links with deleted users are included.
+func (s *Storage) ArchivedFollows(ctx context.Context, userID int64) (_ []models.ExternalFollow, err error) {
+	const op = "sqlite.ArchivedFollows"
+
+	ctx, finish := s.startOperation(ctx, op, slog.Int64("user ID", userID))
+	defer func() { finish(err) }()
+
+	rows, err := s.db.QueryContext(ctx,
+		`select f.id, following.external_id, followed.external_id, f.number_of_likes, f.created_at, f.updated_at
+		from follows f
+		join users following on following.id = f.following_user_id
+		join users followed on followed.id = f.followed_user_id
+		where f.following_user_id = ? or f.followed_user_id = ?
+		order by f.id;`, userID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	follows := make([]models.ExternalFollow, 0)
+	for rows.Next() {
+		var follow models.ExternalFollow
+		err = rows.Scan(
+			&follow.ID,
+			&follow.FollowingExternalID,
+			&follow.FollowedExternalID,
+			&follow.NumberOfLikes,
+			&follow.CreatedAt,
+			&follow.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		follows = append(follows, follow)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return follows, nil
+}
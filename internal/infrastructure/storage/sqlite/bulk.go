@@ -0,0 +1,411 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"love-signal-users/internal/infrastructure/storage/models"
+	"slices"
+	"strings"
+)
+
+// InsertUsers inserts the users in one transaction and returns their IDs in the order of the users.
+// It is the bulk path for filling the database, so the users are inserted as is, including their timestamps.
+func (s *Storage) InsertUsers(ctx context.Context, users []models.User) (_ []int64, err error) {
+	const op = "sqlite.InsertUsers"
+
+	ctx, finish := s.startOperation(ctx, op, slog.Int("users", len(users)))
+	defer func() { finish(err) }()
+
+	ids := make([]int64, 0, len(users))
+	err = s.inTx(ctx, func(tx *sql.Tx) error {
+		stmt, err := tx.PrepareContext(ctx,
+			`insert into users (external_id, full_name, date_of_birth, gender, avatar_file_key, deleted, created_at, updated_at)
+			values (?, ?, ?, ?, ?, ?, ?, ?);`)
+		if err != nil {
+			return err
+		}
+		defer stmt.Close()
+
+		for _, u := range users {
+			res, err := stmt.ExecContext(
+				ctx,
+				u.ExternalID,
+				u.FullName,
+				u.DateOfBirth,
+				u.Gender,
+				u.AvatarFileKey,
+				u.Deleted,
+				u.CreatedAt,
+				u.UpdatedAt,
+			)
+			if err != nil {
+				return err
+			}
+
+			id, err := res.LastInsertId()
+			if err != nil {
+				return err
+			}
+			ids = append(ids, id)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return ids, nil
+}
+
+// InsertFollows inserts the follow links in one transaction, skipping the links that already exist.
+// It returns the number of inserted links.
+func (s *Storage) InsertFollows(ctx context.Context, follows []models.Follow) (_ int64, err error) {
+	const op = "sqlite.InsertFollows"
+
+	ctx, finish := s.startOperation(ctx, op, slog.Int("follows", len(follows)))
+	defer func() { finish(err) }()
+
+	var inserted int64
+	err = s.inTx(ctx, func(tx *sql.Tx) error {
+		stmt, err := tx.PrepareContext(ctx,
+			`insert into follows (following_user_id, followed_user_id, number_of_likes, created_at, updated_at)
+			values (?, ?, ?, ?, ?)
+			on conflict (following_user_id, followed_user_id) do nothing;`)
+		if err != nil {
+			return err
+		}
+		defer stmt.Close()
+
+		for _, f := range follows {
+			res, err := stmt.ExecContext(
+				ctx,
+				f.FollowingUserID,
+				f.FollowedUserID,
+				f.NumberOfLikes,
+				f.CreatedAt,
+				f.UpdatedAt,
+			)
+			if err != nil {
+				return err
+			}
+
+			affected, err := res.RowsAffected()
+			if err != nil {
+				return err
+			}
+			inserted += affected
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return inserted, nil
+}
+
+// inTx runs fn in a transaction, which is committed if fn succeeds and rolled back otherwise.
+func (s *Storage) inTx(ctx context.Context, fn func(tx *sql.Tx) error) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	if err = fn(tx); err != nil {
+		_ = tx.Rollback()
+
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// EachUser calls fn for each user in storage with their interests, including deleted users, in order of ID.
+// The interests are in order of name. The users are read as they are passed, so fn must not call the storage.
+func (s *Storage) EachUser(ctx context.Context, fn func(models.UserWithInterests) error) (err error) {
+	const op = "sqlite.EachUser"
+
+	ctx, finish := s.startOperation(ctx, op)
+	defer func() { finish(err) }()
+
+	// Interest tag names are single words, so they are joined with commas and split back.
+	rows, err := s.db.QueryContext(ctx,
+		`select u.id, u.external_id, u.full_name, u.date_of_birth, u.gender, u.avatar_file_key, u.bio, u.city,
+			u.deleted, u.created_at, u.updated_at,
+			(select group_concat(name, ',') from (
+				select t.name
+				from user_interests ui
+				join interest_tags t on t.id = ui.tag_id
+				where ui.user_id = u.id
+				order by t.name
+			))
+		from users u
+		order by u.id;`)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var user models.UserWithInterests
+		var interests sql.NullString
+		err = rows.Scan(
+			&user.User.ID,
+			&user.User.ExternalID,
+			&user.User.FullName,
+			&user.User.DateOfBirth,
+			&user.User.Gender,
+			&user.User.AvatarFileKey,
+			&user.User.Bio,
+			&user.User.City,
+			&user.User.Deleted,
+			&user.User.CreatedAt,
+			&user.User.UpdatedAt,
+			&interests,
+		)
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+
+		user.Interests = []string{}
+		if interests.Valid {
+			user.Interests = strings.Split(interests.String, ",")
+		}
+
+		if err = fn(user); err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+	if err = rows.Err(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// EachExternalFollow calls fn for each follow link in storage with the external IDs of its users, in order of ID.
+// The links are read as they are passed, so fn must not call the storage.
+func (s *Storage) EachExternalFollow(ctx context.Context, fn func(models.ExternalFollow) error) (err error) {
+	const op = "sqlite.EachExternalFollow"
+
+	ctx, finish := s.startOperation(ctx, op)
+	defer func() { finish(err) }()
+
+	rows, err := s.db.QueryContext(ctx,
+		`select f.id, following.external_id, followed.external_id, f.number_of_likes, f.created_at, f.updated_at
+		from follows f
+		join users following on following.id = f.following_user_id
+		join users followed on followed.id = f.followed_user_id
+		order by f.id;`)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var follow models.ExternalFollow
+		err = rows.Scan(
+			&follow.ID,
+			&follow.FollowingExternalID,
+			&follow.FollowedExternalID,
+			&follow.NumberOfLikes,
+			&follow.CreatedAt,
+			&follow.UpdatedAt,
+		)
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+
+		if err = fn(follow); err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+	if err = rows.Err(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// UpsertUsers inserts the users with their interests in one transaction. Users with the external IDs that are
+// already in storage are updated instead, keeping their IDs and creation times, and their interests are replaced.
+// Users with interests that are not in the dictionary of interest tags are skipped; their indexes are returned.
+func (s *Storage) UpsertUsers(ctx context.Context, users []models.UserWithInterests) (_ []int, err error) {
+	const op = "sqlite.UpsertUsers"
+
+	ctx, finish := s.startOperation(ctx, op, slog.Int("users", len(users)))
+	defer func() { finish(err) }()
+
+	var unknown []int
+	err = s.inTx(ctx, func(tx *sql.Tx) error {
+		tagIDs, err := interestTagIDs(ctx, tx)
+		if err != nil {
+			return err
+		}
+
+		userStmt, err := tx.PrepareContext(ctx,
+			`insert into users (
+				external_id, full_name, date_of_birth, gender, avatar_file_key, bio, city, deleted, created_at, updated_at
+			)
+			values (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+			on conflict (external_id) do update
+			set full_name = excluded.full_name,
+				date_of_birth = excluded.date_of_birth,
+				gender = excluded.gender,
+				avatar_file_key = excluded.avatar_file_key,
+				bio = excluded.bio,
+				city = excluded.city,
+				deleted = excluded.deleted,
+				updated_at = excluded.updated_at
+			returning id;`)
+		if err != nil {
+			return err
+		}
+		defer userStmt.Close()
+
+		deleteStmt, err := tx.PrepareContext(ctx, "delete from user_interests where user_id = ?;")
+		if err != nil {
+			return err
+		}
+		defer deleteStmt.Close()
+
+		insertStmt, err := tx.PrepareContext(ctx,
+			"insert into user_interests (user_id, tag_id, created_at) values (?, ?, ?);")
+		if err != nil {
+			return err
+		}
+		defer insertStmt.Close()
+
+		unknownTag := func(name string) bool {
+			_, ok := tagIDs[name]
+
+			return !ok
+		}
+		for i, u := range users {
+			if slices.ContainsFunc(u.Interests, unknownTag) {
+				unknown = append(unknown, i)
+
+				continue
+			}
+
+			var id int64
+			err = userStmt.QueryRowContext(
+				ctx,
+				u.User.ExternalID,
+				u.User.FullName,
+				u.User.DateOfBirth,
+				u.User.Gender,
+				u.User.AvatarFileKey,
+				u.User.Bio,
+				u.User.City,
+				u.User.Deleted,
+				u.User.CreatedAt,
+				u.User.UpdatedAt,
+			).Scan(&id)
+			if err != nil {
+				return err
+			}
+
+			if _, err = deleteStmt.ExecContext(ctx, id); err != nil {
+				return err
+			}
+			for _, name := range u.Interests {
+				if _, err = insertStmt.ExecContext(ctx, id, tagIDs[name], u.User.UpdatedAt); err != nil {
+					return err
+				}
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return unknown, nil
+}
+
+// interestTagIDs returns the IDs of the interest tags by name.
+func interestTagIDs(ctx context.Context, tx *sql.Tx) (map[string]int64, error) {
+	rows, err := tx.QueryContext(ctx, "select id, name from interest_tags;")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ids := make(map[string]int64)
+	for rows.Next() {
+		var id int64
+		var name string
+		if err = rows.Scan(&id, &name); err != nil {
+			return nil, err
+		}
+
+		ids[name] = id
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return ids, nil
+}
+
+// UpsertExternalFollows inserts the follow links in one transaction, finding their users by external ID.
+// Links between the users that are already linked are updated instead, keeping their IDs and creation times.
+// Links with users that are not in storage are skipped; their indexes are returned.
+func (s *Storage) UpsertExternalFollows(ctx context.Context, follows []models.ExternalFollow) (_ []int, err error) {
+	const op = "sqlite.UpsertExternalFollows"
+
+	ctx, finish := s.startOperation(ctx, op, slog.Int("follows", len(follows)))
+	defer func() { finish(err) }()
+
+	var missing []int
+	err = s.inTx(ctx, func(tx *sql.Tx) error {
+		// The where clause is required by SQLite to parse the upsert of a select.
+		stmt, err := tx.PrepareContext(ctx,
+			`insert into follows (following_user_id, followed_user_id, number_of_likes, created_at, updated_at)
+			select following.id, followed.id, ?, ?, ?
+			from users following, users followed
+			where following.external_id = ? and followed.external_id = ?
+			on conflict (following_user_id, followed_user_id) do update
+			set number_of_likes = excluded.number_of_likes,
+				updated_at = excluded.updated_at;`)
+		if err != nil {
+			return err
+		}
+		defer stmt.Close()
+
+		for i, f := range follows {
+			res, err := stmt.ExecContext(
+				ctx,
+				f.NumberOfLikes,
+				f.CreatedAt,
+				f.UpdatedAt,
+				f.FollowingExternalID,
+				f.FollowedExternalID,
+			)
+			if err != nil {
+				return err
+			}
+
+			affected, err := res.RowsAffected()
+			if err != nil {
+				return err
+			}
+			if affected == 0 {
+				missing = append(missing, i)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return missing, nil
+}
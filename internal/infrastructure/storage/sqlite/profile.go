@@ -0,0 +1,127 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"love-signal-users/internal/infrastructure"
+	"love-signal-users/internal/infrastructure/storage/models"
+	"strings"
+)
+
+// UserInterests returns the names of the interest tags of the user from storage, in order of name.
+func (s *Storage) UserInterests(ctx context.Context, userID int64) (_ []string, err error) {
+	const op = "sqlite.UserInterests"
+
+	ctx, finish := s.startOperation(ctx, op, slog.Int64("user ID", userID))
+	defer func() { finish(err) }()
+
+	rows, err := s.db.QueryContext(ctx,
+		`select t.name
+		from user_interests ui
+		join interest_tags t on t.id = ui.tag_id
+		where ui.user_id = ?
+		order by t.name;`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	interests, err := scanNames(rows)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return interests, nil
+}
+
+// InterestTags returns the names of the canonical interest tags from storage, in order of name.
+func (s *Storage) InterestTags(ctx context.Context) (_ []string, err error) {
+	const op = "sqlite.InterestTags"
+
+	ctx, finish := s.startOperation(ctx, op)
+	defer func() { finish(err) }()
+
+	rows, err := s.db.QueryContext(ctx, "select name from interest_tags order by name;")
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	tags, err := scanNames(rows)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return tags, nil
+}
+
+// UpdateUserProfile replaces the profile fields and the interests of the user in one transaction.
+// Interests that are not in the dictionary of interest tags are skipped.
+func (s *Storage) UpdateUserProfile(ctx context.Context, profile models.UserProfile) (err error) {
+	const op = "sqlite.UpdateUserProfile"
+
+	ctx, finish := s.startOperation(ctx, op, slog.Int64("user ID", profile.UserID))
+	defer func() { finish(err) }()
+
+	err = s.inTx(ctx, func(tx *sql.Tx) error {
+		result, err := tx.ExecContext(ctx,
+			"update users set bio = ?, city = ?, updated_at = ? where id = ? and deleted = false;",
+			profile.Bio, profile.City, profile.UpdatedAt, profile.UserID)
+		if err != nil {
+			return err
+		}
+
+		affected, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if affected == 0 {
+			return infrastructure.ErrEntityNotFound
+		}
+
+		if _, err = tx.ExecContext(ctx, "delete from user_interests where user_id = ?;", profile.UserID); err != nil {
+			return err
+		}
+		if len(profile.Interests) == 0 {
+			return nil
+		}
+
+		placeholders := strings.TrimSuffix(strings.Repeat("?,", len(profile.Interests)), ",")
+		args := make([]any, 0, len(profile.Interests)+2)
+		args = append(args, profile.UserID, profile.UpdatedAt)
+		for _, name := range profile.Interests {
+			args = append(args, name)
+		}
+
+		_, err = tx.ExecContext(ctx, fmt.Sprintf(
+			`insert into user_interests (user_id, tag_id, created_at)
+			select ?, id, ? from interest_tags where name in (%s);`, placeholders), args...)
+
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// scanNames returns the values of the rows of one text column and closes the rows.
+func scanNames(rows *sql.Rows) ([]string, error) {
+	defer rows.Close()
+
+	names := make([]string, 0)
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+
+		names = append(names, name)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return names, nil
+}
@@ -0,0 +1,348 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"love-signal-users/pkg/logger"
+	"love-signal-users/pkg/logger/redact"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// explainTimeout limits EXPLAIN QUERY PLAN of a slow statement, which runs after the statement
+// on a context detached from the cancellation of the statement context.
+const explainTimeout = time.Second
+
+// slowQueryLog logs statements that run longer than the threshold.
+type slowQueryLog struct {
+	log       *slog.Logger
+	threshold time.Duration
+	explain   bool
+}
+
+type operationKey struct{}
+
+// contextWithOperation returns a copy of the context with the name of the storage operation, to log it with its statements.
+func contextWithOperation(ctx context.Context, op string) context.Context {
+	return context.WithValue(ctx, operationKey{}, op)
+}
+
+func operationFromContext(ctx context.Context) string {
+	op, _ := ctx.Value(operationKey{}).(string)
+
+	return op
+}
+
+// connector opens connections whose statements are checked by the slow query log.
+type connector struct {
+	dsn     string
+	driver  driver.Driver
+	slowLog *slowQueryLog
+}
+
+func (c *connector) Connect(context.Context) (driver.Conn, error) {
+	conn, err := c.driver.Open(c.dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	return &slowQueryConn{Conn: conn, slowLog: c.slowLog}, nil
+}
+
+func (c *connector) Driver() driver.Driver {
+	return c.driver
+}
+
+// slowQueryConn is a connection whose statements are timed.
+// It implements the optional driver interfaces and forwards them to the connection it wraps.
+type slowQueryConn struct {
+	driver.Conn
+	slowLog *slowQueryLog
+}
+
+func (c *slowQueryConn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	var stmt driver.Stmt
+	var err error
+	if preparer, ok := c.Conn.(driver.ConnPrepareContext); ok {
+		stmt, err = preparer.PrepareContext(ctx, query)
+	} else {
+		stmt, err = c.Conn.Prepare(query)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &slowQueryStmt{Stmt: stmt, conn: c, query: query}, nil
+}
+
+func (c *slowQueryConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	if beginner, ok := c.Conn.(driver.ConnBeginTx); ok {
+		return beginner.BeginTx(ctx, opts)
+	}
+
+	return c.Conn.Begin()
+}
+
+func (c *slowQueryConn) Ping(ctx context.Context) error {
+	if pinger, ok := c.Conn.(driver.Pinger); ok {
+		return pinger.Ping(ctx)
+	}
+
+	return nil
+}
+
+func (c *slowQueryConn) ResetSession(ctx context.Context) error {
+	if resetter, ok := c.Conn.(driver.SessionResetter); ok {
+		return resetter.ResetSession(ctx)
+	}
+
+	return nil
+}
+
+func (c *slowQueryConn) IsValid() bool {
+	if validator, ok := c.Conn.(driver.Validator); ok {
+		return validator.IsValid()
+	}
+
+	return true
+}
+
+func (c *slowQueryConn) CheckNamedValue(nv *driver.NamedValue) error {
+	if checker, ok := c.Conn.(driver.NamedValueChecker); ok {
+		return checker.CheckNamedValue(nv)
+	}
+
+	// ErrSkip makes database/sql convert the value as if the interface was not implemented.
+	return driver.ErrSkip
+}
+
+func (c *slowQueryConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	execer, ok := c.Conn.(driver.ExecerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+
+	start := time.Now()
+	res, err := execer.ExecContext(ctx, query, args)
+	c.check(ctx, query, args, start)
+
+	return res, err
+}
+
+func (c *slowQueryConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	queryer, ok := c.Conn.(driver.QueryerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+
+	start := time.Now()
+	rows, err := queryer.QueryContext(ctx, query, args)
+	if err != nil {
+		c.check(ctx, query, args, start)
+		return nil, err
+	}
+
+	return &slowQueryRows{Rows: rows, done: func() { c.check(ctx, query, args, start) }}, nil
+}
+
+// check logs the statement if it has run longer than the threshold.
+func (c *slowQueryConn) check(ctx context.Context, query string, args []driver.NamedValue, start time.Time) {
+	duration := time.Since(start)
+	if duration < c.slowLog.threshold {
+		return
+	}
+
+	attrs := []any{
+		slog.String("op", operationFromContext(ctx)),
+		slog.String("sql", compactQuery(query)),
+		slog.Any("params", redactArgs(args)),
+		slog.Duration("duration", duration),
+	}
+
+	if c.slowLog.explain {
+		// The statement context may be cancelled already, e.g. when rows are closed after a cancelled read.
+		explainCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), explainTimeout)
+		plan, err := c.explainQueryPlan(explainCtx, query, args)
+		cancel()
+		if err != nil {
+			attrs = append(attrs, slog.String("query plan error", err.Error()))
+		} else {
+			attrs = append(attrs, slog.String("query plan", plan))
+		}
+	}
+
+	logger.FromContext(ctx, c.slowLog.log).Warn("slow query", attrs...)
+}
+
+// explainQueryPlan returns the details of EXPLAIN QUERY PLAN of the statement, one step per line.
+func (c *slowQueryConn) explainQueryPlan(ctx context.Context, query string, args []driver.NamedValue) (string, error) {
+	queryer, ok := c.Conn.(driver.QueryerContext)
+	if !ok {
+		return "", errors.New("connection does not support queries")
+	}
+
+	rows, err := queryer.QueryContext(ctx, "explain query plan "+query, args)
+	if err != nil {
+		return "", err
+	}
+	defer rows.Close()
+
+	// Columns are id, parent, notused and detail.
+	steps := make([]string, 0)
+	values := make([]driver.Value, len(rows.Columns()))
+	for {
+		if err = rows.Next(values); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+
+			return "", err
+		}
+
+		steps = append(steps, fmt.Sprint(values[len(values)-1]))
+	}
+
+	return strings.Join(steps, "\n"), nil
+}
+
+// slowQueryStmt is a prepared statement whose executions are timed.
+type slowQueryStmt struct {
+	driver.Stmt
+	conn  *slowQueryConn
+	query string
+}
+
+func (s *slowQueryStmt) CheckNamedValue(nv *driver.NamedValue) error {
+	if checker, ok := s.Stmt.(driver.NamedValueChecker); ok {
+		return checker.CheckNamedValue(nv)
+	}
+
+	return driver.ErrSkip
+}
+
+func (s *slowQueryStmt) ExecContext(ctx context.Context, args []driver.NamedValue) (driver.Result, error) {
+	execer, ok := s.Stmt.(driver.StmtExecContext)
+	if !ok {
+		return nil, errors.New("statement does not support ExecContext")
+	}
+
+	start := time.Now()
+	res, err := execer.ExecContext(ctx, args)
+	s.conn.check(ctx, s.query, args, start)
+
+	return res, err
+}
+
+func (s *slowQueryStmt) QueryContext(ctx context.Context, args []driver.NamedValue) (driver.Rows, error) {
+	queryer, ok := s.Stmt.(driver.StmtQueryContext)
+	if !ok {
+		return nil, errors.New("statement does not support QueryContext")
+	}
+
+	start := time.Now()
+	rows, err := queryer.QueryContext(ctx, args)
+	if err != nil {
+		s.conn.check(ctx, s.query, args, start)
+		return nil, err
+	}
+
+	return &slowQueryRows{Rows: rows, done: func() { s.conn.check(ctx, s.query, args, start) }}, nil
+}
+
+// slowQueryRows are rows of a query, which is timed until the rows are closed,
+// because SQLite steps through the statement while the rows are read.
+type slowQueryRows struct {
+	driver.Rows
+	done func()
+}
+
+func (r *slowQueryRows) Close() error {
+	err := r.Rows.Close()
+	r.done()
+
+	return err
+}
+
+// The column type methods return what database/sql assumes for rows that do not implement them.
+
+func (r *slowQueryRows) ColumnTypeDatabaseTypeName(index int) string {
+	if typed, ok := r.Rows.(driver.RowsColumnTypeDatabaseTypeName); ok {
+		return typed.ColumnTypeDatabaseTypeName(index)
+	}
+
+	return ""
+}
+
+func (r *slowQueryRows) ColumnTypeScanType(index int) reflect.Type {
+	if typed, ok := r.Rows.(driver.RowsColumnTypeScanType); ok {
+		return typed.ColumnTypeScanType(index)
+	}
+
+	return reflect.TypeFor[any]()
+}
+
+func (r *slowQueryRows) ColumnTypeNullable(index int) (bool, bool) {
+	if typed, ok := r.Rows.(driver.RowsColumnTypeNullable); ok {
+		return typed.ColumnTypeNullable(index)
+	}
+
+	return false, false
+}
+
+func (r *slowQueryRows) ColumnTypeLength(index int) (int64, bool) {
+	if typed, ok := r.Rows.(driver.RowsColumnTypeLength); ok {
+		return typed.ColumnTypeLength(index)
+	}
+
+	return 0, false
+}
+
+func (r *slowQueryRows) ColumnTypePrecisionScale(index int) (int64, int64, bool) {
+	if typed, ok := r.Rows.(driver.RowsColumnTypePrecisionScale); ok {
+		return typed.ColumnTypePrecisionScale(index)
+	}
+
+	return 0, 0, false
+}
+
+func (r *slowQueryRows) HasNextResultSet() bool {
+	if sets, ok := r.Rows.(driver.RowsNextResultSet); ok {
+		return sets.HasNextResultSet()
+	}
+
+	return false
+}
+
+func (r *slowQueryRows) NextResultSet() error {
+	if sets, ok := r.Rows.(driver.RowsNextResultSet); ok {
+		return sets.NextResultSet()
+	}
+
+	return io.EOF
+}
+
+// redactArgs returns the statement arguments to log. Numbers, booleans and nulls are kept,
+// as they are identifiers, counters and flags needed to reproduce the query plan; other values may hold PII and are masked.
+func redactArgs(args []driver.NamedValue) []any {
+	values := make([]any, 0, len(args))
+	for _, arg := range args {
+		switch arg.Value.(type) {
+		case nil, int64, float64, bool:
+			values = append(values, arg.Value)
+		default:
+			values = append(values, redact.Mask)
+		}
+	}
+
+	return values
+}
+
+// compactQuery returns the query on one line.
+func compactQuery(query string) string {
+	return strings.Join(strings.Fields(query), " ")
+}
@@ -7,31 +7,157 @@ import (
 	"fmt"
 	"github.com/mattn/go-sqlite3"
 	_ "github.com/mattn/go-sqlite3"
+	"log/slog"
 	"love-signal-users/internal/infrastructure"
 	"love-signal-users/internal/infrastructure/storage/models"
 	"strings"
+	"time"
 )
 
 type Storage struct {
-	db *sql.DB
+	db      *sql.DB
+	hooks   []Hook
+	slowLog *slowQueryLog
+}
+
+// Hook is called at the start of each storage operation with the operation name and the attributes
+// of the operation, the same as in the log records of the layers above, e.g. user and follow link IDs.
+// It returns the context for the operation and a function that is called with the operation result.
+type Hook func(ctx context.Context, op string, attrs ...slog.Attr) (context.Context, func(err error))
+
+// Option is how options for the Storage are set up.
+type Option func(*Storage)
+
+// WithHooks adds hooks called around each storage operation.
+func WithHooks(hooks ...Hook) Option {
+	return func(s *Storage) {
+		s.hooks = append(s.hooks, hooks...)
+	}
+}
+
+// WithSlowQueryLog sets up logging of statements that run longer than the threshold,
+// with their operation, SQL, redacted parameters and duration.
+// If explain is set, the EXPLAIN QUERY PLAN output of the statement is logged too.
+func WithSlowQueryLog(log *slog.Logger, threshold time.Duration, explain bool) Option {
+	return func(s *Storage) {
+		s.slowLog = &slowQueryLog{
+			log:       log,
+			threshold: threshold,
+			explain:   explain,
+		}
+	}
 }
 
 // New creates a new SQLite storage.
-func New(storagePath string) (*Storage, error) {
+func New(storagePath string, opts ...Option) (*Storage, error) {
 	const op = "storage.sqlite.New"
 
-	db, err := sql.Open("sqlite3", storagePath)
+	s := &Storage{}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	if s.slowLog == nil {
+		db, err := sql.Open("sqlite3", storagePath)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		s.db = db
+
+		return s, nil
+	}
+
+	s.db = sql.OpenDB(&connector{
+		dsn:     storagePath,
+		driver:  &sqlite3.SQLiteDriver{},
+		slowLog: s.slowLog,
+	})
+
+	return s, nil
+}
+
+// Close closes the database. Operations still running fail.
+func (s *Storage) Close() error {
+	const op = "storage.sqlite.Close"
+
+	if err := s.db.Close(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// Stats returns the database connection pool statistics.
+func (s *Storage) Stats() sql.DBStats {
+	return s.db.Stats()
+}
+
+// Ping checks that the database is accessible.
+func (s *Storage) Ping(ctx context.Context) (err error) {
+	const op = "sqlite.Ping"
+
+	ctx, finish := s.startOperation(ctx, op)
+	defer func() { finish(err) }()
+
+	if err = s.db.PingContext(ctx); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// MigrationVersion returns the version of the applied migrations from the migrations table
+// and whether the last migration has failed and left the database dirty.
+func (s *Storage) MigrationVersion(ctx context.Context, migrationsTable string) (_ uint, _ bool, err error) {
+	const op = "sqlite.MigrationVersion"
+
+	ctx, finish := s.startOperation(ctx, op)
+	defer func() { finish(err) }()
+
+	query := fmt.Sprintf(
+		`select version, dirty from "%s" limit 1;`,
+		strings.ReplaceAll(migrationsTable, `"`, `""`),
+	)
+
+	var version uint
+	var dirty bool
+	err = s.db.QueryRowContext(ctx, query).Scan(&version, &dirty)
 	if err != nil {
-		return nil, fmt.Errorf("%s: %w", op, err)
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, false, fmt.Errorf("%s: %w", op, infrastructure.ErrEntityNotFound)
+		}
+
+		return 0, false, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return version, dirty, nil
+}
+
+// startOperation calls the hooks at the start of the operation.
+// The returned function must be called with the operation result.
+func (s *Storage) startOperation(ctx context.Context, op string, attrs ...slog.Attr) (context.Context, func(err error)) {
+	ctx = contextWithOperation(ctx, op)
+
+	finishes := make([]func(err error), len(s.hooks))
+	for i, hook := range s.hooks {
+		ctx, finishes[i] = hook(ctx, op, attrs...)
 	}
 
-	return &Storage{db: db}, nil
+	return ctx, func(err error) {
+		for i := len(finishes) - 1; i >= 0; i-- {
+			finishes[i](err)
+		}
+	}
 }
 
 // Users returns slice of users by ids from storage.
-func (s *Storage) Users(ctx context.Context, ids []int64) ([]models.User, error) {
+func (s *Storage) Users(ctx context.Context, ids []int64) (_ []models.User, err error) {
 	const op = "sqlite.Users"
 
+	ctx, finish := s.startOperation(ctx, op, slog.Int("users", len(ids)))
+	defer func() { finish(err) }()
+
 	// Generate the placeholders for the IN clause.
 	placeholders := make([]string, len(ids))
 	for i := range ids {
@@ -47,6 +173,8 @@ func (s *Storage) Users(ctx context.Context, ids []int64) ([]models.User, error)
 			u.date_of_birth,
 			u.gender,
 			u.avatar_file_key,
+			u.bio,
+			u.city,
 			u.deleted,
 			u.created_at,
 			u.updated_at
@@ -79,6 +207,8 @@ func (s *Storage) Users(ctx context.Context, ids []int64) ([]models.User, error)
 			&user.DateOfBirth,
 			&user.Gender,
 			&user.AvatarFileKey,
+			&user.Bio,
+			&user.City,
 			&user.Deleted,
 			&user.CreatedAt,
 			&user.UpdatedAt,
@@ -95,9 +225,12 @@ func (s *Storage) Users(ctx context.Context, ids []int64) ([]models.User, error)
 }
 
 // User returns information about a user by their ID from storage.
-func (s *Storage) User(ctx context.Context, userID int64) (models.User, error) {
+func (s *Storage) User(ctx context.Context, userID int64) (_ models.User, err error) {
 	const op = "sqlite.User"
 
+	ctx, finish := s.startOperation(ctx, op, slog.Int64("user ID", userID))
+	defer func() { finish(err) }()
+
 	stmt, err := s.db.PrepareContext(ctx,
 		`select
     	u.id,
@@ -106,6 +239,8 @@ func (s *Storage) User(ctx context.Context, userID int64) (models.User, error) {
     	u.date_of_birth,
     	u.gender,
     	u.avatar_file_key,
+    	u.bio,
+    	u.city,
     	u.deleted,
     	u.created_at,
     	u.updated_at
@@ -126,6 +261,8 @@ func (s *Storage) User(ctx context.Context, userID int64) (models.User, error) {
 		&user.DateOfBirth,
 		&user.Gender,
 		&user.AvatarFileKey,
+		&user.Bio,
+		&user.City,
 		&user.Deleted,
 		&user.CreatedAt,
 		&user.UpdatedAt,
@@ -142,9 +279,12 @@ func (s *Storage) User(ctx context.Context, userID int64) (models.User, error) {
 }
 
 // UserByExternalID returns information about a user by their external ID from storage.
-func (s *Storage) UserByExternalID(ctx context.Context, externalID int64) (models.User, error) {
+func (s *Storage) UserByExternalID(ctx context.Context, externalID int64) (_ models.User, err error) {
 	const op = "sqlite.UserDataByExternalID"
 
+	ctx, finish := s.startOperation(ctx, op, slog.Int64("user external ID", externalID))
+	defer func() { finish(err) }()
+
 	stmt, err := s.db.PrepareContext(ctx,
 		`select
     	u.id,
@@ -153,11 +293,13 @@ func (s *Storage) UserByExternalID(ctx context.Context, externalID int64) (model
     	u.date_of_birth,
     	u.gender,
     	u.avatar_file_key,
+    	u.bio,
+    	u.city,
     	u.deleted,
     	u.created_at,
     	u.updated_at
 		from users u
-		where u.deleted = false and u.id = ?;`)
+		where u.deleted = false and u.external_id = ?;`)
 
 	if err != nil {
 		return models.User{}, fmt.Errorf("%s: %w", op, err)
@@ -173,6 +315,8 @@ func (s *Storage) UserByExternalID(ctx context.Context, externalID int64) (model
 		&user.DateOfBirth,
 		&user.Gender,
 		&user.AvatarFileKey,
+		&user.Bio,
+		&user.City,
 		&user.Deleted,
 		&user.CreatedAt,
 		&user.UpdatedAt,
@@ -192,9 +336,12 @@ func (s *Storage) UserByExternalID(ctx context.Context, externalID int64) (model
 func (s *Storage) FollowsByUserID(
 	ctx context.Context,
 	userID int64,
-) ([]models.Follow, error) {
+) (_ []models.Follow, err error) {
 	const op = "sqlite.FollowedUsers"
 
+	ctx, finish := s.startOperation(ctx, op, slog.Int64("user ID", userID))
+	defer func() { finish(err) }()
+
 	stmt, err := s.db.PrepareContext(ctx,
 		`select
     	f.id,
@@ -239,13 +386,92 @@ func (s *Storage) FollowsByUserID(
 	return follows, nil
 }
 
+// Follow returns the follow link by its ID from storage.
+func (s *Storage) Follow(ctx context.Context, followLinkID int64) (_ models.Follow, err error) {
+	const op = "sqlite.Follow"
+
+	ctx, finish := s.startOperation(ctx, op, slog.Int64("follow link ID", followLinkID))
+	defer func() { finish(err) }()
+
+	stmt, err := s.db.PrepareContext(ctx,
+		`select
+    	f.id,
+    	f.following_user_id,
+    	f.followed_user_id,
+    	f.number_of_likes,
+    	f.created_at,
+    	f.updated_at
+		from follows f
+		where f.id = ?;`)
+
+	if err != nil {
+		return models.Follow{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	row := stmt.QueryRowContext(ctx, followLinkID)
+
+	var follow models.Follow
+	err = row.Scan(
+		&follow.ID,
+		&follow.FollowingUserID,
+		&follow.FollowedUserID,
+		&follow.NumberOfLikes,
+		&follow.CreatedAt,
+		&follow.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.Follow{}, fmt.Errorf("%s: %w", op, infrastructure.ErrEntityNotFound)
+		}
+
+		return models.Follow{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return follow, nil
+}
+
+// FollowExists checks if the following user is followed to the followed user in storage.
+func (s *Storage) FollowExists(ctx context.Context, followingUserID, followedUserID int64) (_ bool, err error) {
+	const op = "sqlite.FollowExists"
+
+	ctx, finish := s.startOperation(ctx, op,
+		slog.Int64("following user ID", followingUserID),
+		slog.Int64("followed user ID", followedUserID),
+	)
+	defer func() { finish(err) }()
+
+	stmt, err := s.db.PrepareContext(ctx,
+		`select exists(
+			select 1
+			from follows f
+			where f.following_user_id = ? and f.followed_user_id = ?
+		);`)
+
+	if err != nil {
+		return false, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var exists bool
+	if err = stmt.QueryRowContext(ctx, followingUserID, followedUserID).Scan(&exists); err != nil {
+		return false, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return exists, nil
+}
+
 // CreateFollow creates the follow link in storage.
-func (s *Storage) CreateFollow(ctx context.Context, follow models.Follow) (int64, error) {
+func (s *Storage) CreateFollow(ctx context.Context, follow models.Follow) (_ int64, err error) {
 	const op = "sqlite.CreateFollow"
 
+	ctx, finish := s.startOperation(ctx, op,
+		slog.Int64("following user ID", follow.FollowingUserID),
+		slog.Int64("followed user ID", follow.FollowedUserID),
+	)
+	defer func() { finish(err) }()
+
 	stmt, err := s.db.PrepareContext(ctx,
 		`insert into follows (following_user_id, followed_user_id, number_of_likes, created_at, updated_at)
-		values (?, ?, ?, ?);`)
+		values (?, ?, ?, ?, ?);`)
 
 	if err != nil {
 		return 0, fmt.Errorf("%s: %w", op, err)
@@ -277,9 +503,12 @@ func (s *Storage) CreateFollow(ctx context.Context, follow models.Follow) (int64
 }
 
 // UpdateFollow updates the follow link in storage.
-func (s *Storage) UpdateFollow(ctx context.Context, follow models.Follow) error {
+func (s *Storage) UpdateFollow(ctx context.Context, follow models.Follow) (err error) {
 	const op = "sqlite.UpdateFollow"
 
+	ctx, finish := s.startOperation(ctx, op, slog.Int64("follow link ID", follow.ID))
+	defer func() { finish(err) }()
+
 	stmt, err := s.db.PrepareContext(ctx,
 		`update follows
 		 set following_user_id = ?,
@@ -308,9 +537,12 @@ func (s *Storage) UpdateFollow(ctx context.Context, follow models.Follow) error
 }
 
 // RemoveFollow removes the follow link from storage by followLinkID.
-func (s *Storage) RemoveFollow(ctx context.Context, followLinkID int64) error {
+func (s *Storage) RemoveFollow(ctx context.Context, followLinkID int64) (err error) {
 	const op = "sqlite.RemoveFollow"
 
+	ctx, finish := s.startOperation(ctx, op, slog.Int64("follow link ID", followLinkID))
+	defer func() { finish(err) }()
+
 	stmt, err := s.db.PrepareContext(ctx, "delete from follows where id = ?;")
 
 	if err != nil {
@@ -324,3 +556,117 @@ func (s *Storage) RemoveFollow(ctx context.Context, followLinkID int64) error {
 
 	return nil
 }
+
+// SetUserDeleted marks the user as deleted or restores them. Deleted users are not returned by other operations.
+func (s *Storage) SetUserDeleted(ctx context.Context, userID int64, deleted bool) (err error) {
+	const op = "sqlite.SetUserDeleted"
+
+	ctx, finish := s.startOperation(ctx, op, slog.Int64("user ID", userID), slog.Bool("deleted", deleted))
+	defer func() { finish(err) }()
+
+	stmt, err := s.db.PrepareContext(ctx, "update users set deleted = ?, updated_at = ? where id = ?;")
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	result, err := stmt.ExecContext(ctx, deleted, time.Now(), userID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if affected == 0 {
+		return fmt.Errorf("%s: %w", op, infrastructure.ErrEntityNotFound)
+	}
+
+	return nil
+}
+
+// DatabaseStats returns the numbers of rows and the size of the database.
+func (s *Storage) DatabaseStats(ctx context.Context) (_ models.DatabaseStats, err error) {
+	const op = "sqlite.DatabaseStats"
+
+	ctx, finish := s.startOperation(ctx, op)
+	defer func() { finish(err) }()
+
+	var stats models.DatabaseStats
+	err = s.db.QueryRowContext(ctx,
+		`select
+		(select count(*) from users where deleted = false),
+		(select count(*) from users where deleted = true),
+		(select count(*) from follows),
+		(select coalesce(sum(number_of_likes), 0) from follows),
+		(select page_count * page_size from pragma_page_count(), pragma_page_size());`,
+	).Scan(
+		&stats.Users,
+		&stats.DeletedUsers,
+		&stats.Follows,
+		&stats.Likes,
+		&stats.SizeBytes,
+	)
+	if err != nil {
+		return models.DatabaseStats{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return stats, nil
+}
+
+// SampleUserKeys returns the IDs of up to limit random users that are not deleted.
+func (s *Storage) SampleUserKeys(ctx context.Context, limit int) (_ []models.UserKey, err error) {
+	const op = "sqlite.SampleUserKeys"
+
+	ctx, finish := s.startOperation(ctx, op, slog.Int("limit", limit))
+	defer func() { finish(err) }()
+
+	rows, err := s.db.QueryContext(ctx,
+		"select id, external_id from users where deleted = false order by random() limit ?;", limit)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	keys := make([]models.UserKey, 0, limit)
+	for rows.Next() {
+		var key models.UserKey
+		if err = rows.Scan(&key.ID, &key.ExternalID); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		keys = append(keys, key)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return keys, nil
+}
+
+// SampleFollowLinkIDs returns the IDs of up to limit random follow links.
+func (s *Storage) SampleFollowLinkIDs(ctx context.Context, limit int) (_ []int64, err error) {
+	const op = "sqlite.SampleFollowLinkIDs"
+
+	ctx, finish := s.startOperation(ctx, op, slog.Int("limit", limit))
+	defer func() { finish(err) }()
+
+	rows, err := s.db.QueryContext(ctx, "select id from follows order by random() limit ?;", limit)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	ids := make([]int64, 0, limit)
+	for rows.Next() {
+		var id int64
+		if err = rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		ids = append(ids, id)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return ids, nil
+}
@@ -0,0 +1,196 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+	"log/slog"
+	"love-signal-users/internal/entity"
+	"love-signal-users/internal/enum"
+	"love-signal-users/internal/infrastructure"
+	"love-signal-users/internal/infrastructure/storage/sqlite"
+	"net/http"
+	"time"
+)
+
+const namespace = "users"
+
+// Metrics is a set of the application metrics.
+type Metrics struct {
+	registry *prometheus.Registry
+
+	rpcHandled  *prometheus.CounterVec
+	rpcDuration *prometheus.HistogramVec
+
+	storageDuration *prometheus.HistogramVec
+	storageErrors   *prometheus.CounterVec
+
+	followsCreated prometheus.Counter
+	unfollows      prometheus.Counter
+	likes          prometheus.Counter
+}
+
+// New returns new application metrics registered in a new registry.
+func New() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+
+		rpcHandled: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "grpc",
+			Name:      "handled_total",
+			Help:      "Total number of RPCs completed on the server, by method and code.",
+		}, []string{"method", "code"}),
+		rpcDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "grpc",
+			Name:      "handling_seconds",
+			Help:      "Duration of RPCs handled by the server, by method and code.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "code"}),
+
+		storageDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "storage",
+			Name:      "operation_seconds",
+			Help:      "Duration of storage operations, by operation.",
+			Buckets:   []float64{.0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5, 1},
+		}, []string{"operation"}),
+		storageErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "storage",
+			Name:      "operation_errors_total",
+			Help:      "Total number of failed storage operations, by operation. Not found entities are not counted.",
+		}, []string{"operation"}),
+
+		followsCreated: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "follows_created_total",
+			Help:      "Total number of created follows.",
+		}),
+		unfollows: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "unfollows_total",
+			Help:      "Total number of removed follows.",
+		}),
+		likes: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "likes_total",
+			Help:      "Total number of received likes.",
+		}),
+	}
+
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.rpcHandled,
+		m.rpcDuration,
+		m.storageDuration,
+		m.storageErrors,
+		m.followsCreated,
+		m.unfollows,
+		m.likes,
+	)
+
+	return m
+}
+
+// Handler returns an HTTP handler serving the metrics.
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{})
+}
+
+// RegisterDBStats registers the database connection pool statistics of the source.
+func (m *Metrics) RegisterDBStats(source DBStatsSource) {
+	m.registry.MustRegister(newDBStatsCollector(source))
+}
+
+// UnaryServerInterceptor returns a unary server interceptor that counts and times RPCs.
+func (m *Metrics) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req any,
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (any, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		m.observeRPC(info.FullMethod, start, err)
+
+		return resp, err
+	}
+}
+
+// StreamServerInterceptor returns a stream server interceptor that counts and times RPCs.
+func (m *Metrics) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(
+		srv any,
+		ss grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		start := time.Now()
+		err := handler(srv, ss)
+		m.observeRPC(info.FullMethod, start, err)
+
+		return err
+	}
+}
+
+func (m *Metrics) observeRPC(method string, start time.Time, err error) {
+	code := status.Code(err).String()
+
+	m.rpcHandled.WithLabelValues(method, code).Inc()
+	m.rpcDuration.WithLabelValues(method, code).Observe(time.Since(start).Seconds())
+}
+
+// StorageHook returns a storage hook that times storage operations and counts their errors.
+// Operations are labeled with their op names, e.g. "sqlite.CreateFollow".
+func (m *Metrics) StorageHook() sqlite.Hook {
+	return func(ctx context.Context, op string, _ ...slog.Attr) (context.Context, func(err error)) {
+		start := time.Now()
+
+		return ctx, func(err error) {
+			m.storageDuration.WithLabelValues(op).Observe(time.Since(start).Seconds())
+
+			if err != nil && !errors.Is(err, infrastructure.ErrEntityNotFound) {
+				m.storageErrors.WithLabelValues(op).Inc()
+			}
+		}
+	}
+}
+
+// Publisher is a follow events publisher.
+type Publisher interface {
+	Publish(userID int64, event entity.FollowEvent)
+}
+
+// CountingPublisher returns a follow events publisher that counts domain events before publishing them to next.
+func (m *Metrics) CountingPublisher(next Publisher) Publisher {
+	return &countingPublisher{
+		metrics: m,
+		next:    next,
+	}
+}
+
+type countingPublisher struct {
+	metrics *Metrics
+	next    Publisher
+}
+
+func (p *countingPublisher) Publish(userID int64, event entity.FollowEvent) {
+	switch event.Type {
+	case enum.NewFollower:
+		p.metrics.followsCreated.Inc()
+	case enum.Unfollowed:
+		p.metrics.unfollows.Inc()
+	case enum.LikeReceived:
+		p.metrics.likes.Inc()
+	}
+
+	p.next.Publish(userID, event)
+}
@@ -0,0 +1,82 @@
+package deactivate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"love-signal-users/internal/entity"
+	"love-signal-users/internal/enum"
+	"love-signal-users/internal/infrastructure"
+	"love-signal-users/internal/usecase"
+	"love-signal-users/pkg/caller"
+	"love-signal-users/pkg/logger"
+	"love-signal-users/pkg/logger/sl"
+	"love-signal-users/pkg/tracing"
+)
+
+// Repository is a repository for deactivate user use-case.
+type Repository interface {
+	SetUserDeleted(ctx context.Context, id int64, deleted bool) error
+}
+
+// Auditor records state-changing calls to the audit log.
+type Auditor interface {
+	Audit(ctx context.Context, record entity.AuditRecord)
+}
+
+// UseCase is a use-case for deactivating users.
+type UseCase struct {
+	log     *slog.Logger
+	repo    Repository
+	auditor Auditor
+}
+
+// New returns new deactivate user use-case.
+func New(log *slog.Logger, repo Repository, auditor Auditor) *UseCase {
+	return &UseCase{
+		log:     log,
+		repo:    repo,
+		auditor: auditor,
+	}
+}
+
+// Execute executes the use-case for deactivating user.
+// A deactivated user is hidden from lookups and follow lists until restored; their follows are kept.
+func (uc *UseCase) Execute(ctx context.Context, userID int64) (err error) {
+	const op = "usecase.deactivate.Execute"
+
+	ctx, span := tracing.Start(ctx, op, slog.Int64("user ID", userID))
+	defer tracing.Finish(span, &err)
+
+	log := logger.FromContext(ctx, uc.log).With(
+		slog.String("op", op),
+		slog.Int64("user ID", userID),
+	)
+
+	// The action is taken by an operator, who is identified by the caller of the admin call.
+	defer func() {
+		uc.auditor.Audit(ctx, entity.NewAuditRecord(
+			enum.AuditDeactivate,
+			entity.OperatorRef(caller.FromContext(ctx)),
+			entity.UserRef(userID),
+			err,
+		))
+	}()
+
+	if err = uc.repo.SetUserDeleted(ctx, userID, true); err != nil {
+		if errors.Is(err, infrastructure.ErrEntityNotFound) {
+			log.Warn("user not found", sl.Err(err))
+
+			return fmt.Errorf("%s: %w", op, usecase.ErrUserNotFound)
+		}
+
+		log.Error("error deactivating user", sl.Err(err))
+
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("user deactivated")
+
+	return nil
+}
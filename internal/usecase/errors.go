@@ -1,7 +1,22 @@
 package usecase
 
-import "errors"
+import (
+	"errors"
+	"strings"
+)
 
 var (
-	ErrUserNotFound = errors.New("user not found")
+	ErrUserNotFound   = errors.New("user not found")
+	ErrFollowNotFound = errors.New("follow not found")
+	ErrFollowExists   = errors.New("follow already exists")
 )
+
+// InvalidProfileError is returned when the extended profile to set is invalid.
+// The problems describe the invalid fields and can be shown to the caller.
+type InvalidProfileError struct {
+	Problems []string
+}
+
+func (e *InvalidProfileError) Error() string {
+	return "invalid profile: " + strings.Join(e.Problems, "; ")
+}
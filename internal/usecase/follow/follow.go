@@ -2,46 +2,97 @@ package follow
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"love-signal-users/internal/dto"
 	"love-signal-users/internal/entity"
+	"love-signal-users/internal/enum"
+	"love-signal-users/internal/infrastructure"
+	"love-signal-users/internal/usecase"
+	"love-signal-users/pkg/logger"
 	"love-signal-users/pkg/logger/sl"
+	"love-signal-users/pkg/tracing"
 )
 
 // Repository is a repository for follow user use-case.
 type Repository interface {
+	User(ctx context.Context, id int64) (dto.User, error)
 	SaveFollow(ctx context.Context, follow *entity.Follow) error
+	FollowExists(ctx context.Context, followingUserID, followedUserID int64) (bool, error)
+}
+
+// Publisher publishes follow events to the user.
+type Publisher interface {
+	Publish(userID int64, event entity.FollowEvent)
+}
+
+// Auditor records state-changing calls to the audit log.
+type Auditor interface {
+	Audit(ctx context.Context, record entity.AuditRecord)
 }
 
 // UseCase is a use-case for following users.
 type UseCase struct {
-	log  *slog.Logger
-	repo Repository
+	log       *slog.Logger
+	repo      Repository
+	publisher Publisher
+	auditor   Auditor
 }
 
 // New returns new follow user use-case.
-func New(log *slog.Logger, repo Repository) *UseCase {
+func New(log *slog.Logger, repo Repository, publisher Publisher, auditor Auditor) *UseCase {
 	return &UseCase{
-		log:  log,
-		repo: repo,
+		log:       log,
+		repo:      repo,
+		publisher: publisher,
+		auditor:   auditor,
 	}
 }
 
-// Execute executes the use-case for following user.
+// Execute executes the use-case for following user. Both users must exist, and the user must not follow
+// the other one already.
 func (uc *UseCase) Execute(
 	ctx context.Context,
 	userID int64,
 	userIDToFollow int64,
-) error {
+) (err error) {
 	const op = "usecase.follow.Execute"
 
-	log := uc.log.With(
+	ctx, span := tracing.Start(ctx, op,
+		slog.Int64("user ID", userID),
+		slog.Int64("user ID to follow", userIDToFollow),
+	)
+	defer tracing.Finish(span, &err)
+
+	log := logger.FromContext(ctx, uc.log).With(
 		slog.String("op", op),
 		slog.Int64("user ID", userID),
 		slog.Int64("user ID to follow", userIDToFollow),
 	)
 
+	defer func() {
+		uc.auditor.Audit(
+			ctx,
+			entity.NewAuditRecord(enum.AuditFollow, entity.UserRef(userID), entity.UserRef(userIDToFollow), err),
+		)
+	}()
+
+	// Storage does not enforce the users of follow links, so they are checked before the link is saved.
+	for _, id := range []int64{userID, userIDToFollow} {
+		if _, err = uc.repo.User(ctx, id); err != nil {
+			if errors.Is(err, infrastructure.ErrEntityNotFound) {
+				log.Warn("user not found", slog.Int64("missing user ID", id), sl.Err(err))
+
+				return fmt.Errorf("%s: %w", op, usecase.ErrUserNotFound)
+			}
+
+			log.Error("error getting user", sl.Err(err))
+
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
 	followDTO := dto.Follow{
 		FollowingUser: dto.User{ID: userID},
 		FollowedUser:  dto.User{ID: userIDToFollow},
@@ -50,13 +101,35 @@ func (uc *UseCase) Execute(
 	followEntity := entity.NewFollow(followDTO)
 	followEntity.SetToCreate()
 
-	if err := uc.repo.SaveFollow(ctx, &followEntity); err != nil {
-		// TODO: handle check FK constraint on user
+	if err = uc.repo.SaveFollow(ctx, &followEntity); err != nil {
+		if errors.Is(err, infrastructure.ErrFollowExist) {
+			log.Warn("follow already exists", sl.Err(err))
+
+			return fmt.Errorf("%s: %w", op, usecase.ErrFollowExists)
+		}
 
 		log.Error("error saving follow", sl.Err(err))
 
 		return fmt.Errorf("%s: %w", op, err)
 	}
 
+	uc.publisher.Publish(
+		userIDToFollow,
+		entity.NewFollowEvent(enum.NewFollower, userIDToFollow, userID, followEntity.ID),
+	)
+
+	// The follow is already saved, so an error of match detection does not fail the use-case.
+	isMutual, err := uc.repo.FollowExists(ctx, userIDToFollow, userID)
+	if err != nil {
+		log.Warn("error checking mutual follow", sl.Err(err))
+
+		return nil
+	}
+
+	if isMutual {
+		uc.publisher.Publish(userID, entity.NewFollowEvent(enum.Match, userID, userIDToFollow, followEntity.ID))
+		uc.publisher.Publish(userIDToFollow, entity.NewFollowEvent(enum.Match, userIDToFollow, userID, followEntity.ID))
+	}
+
 	return nil
 }
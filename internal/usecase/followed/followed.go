@@ -6,7 +6,9 @@ import (
 	"log/slog"
 	"love-signal-users/internal/dto"
 	"love-signal-users/internal/entity"
+	"love-signal-users/pkg/logger"
 	"love-signal-users/pkg/logger/sl"
+	"love-signal-users/pkg/tracing"
 )
 
 // Repository is a repository for followed users use-case.
@@ -29,10 +31,13 @@ func New(log *slog.Logger, repo Repository) *UseCase {
 }
 
 // Execute executes the use-case for getting followed users.
-func (uc *UseCase) Execute(ctx context.Context, userID int64) ([]entity.Follow, error) {
+func (uc *UseCase) Execute(ctx context.Context, userID int64) (_ []entity.Follow, err error) {
 	const op = "usecase.followed.Execute"
 
-	log := uc.log.With(
+	ctx, span := tracing.Start(ctx, op, slog.Int64("user ID", userID))
+	defer tracing.Finish(span, &err)
+
+	log := logger.FromContext(ctx, uc.log).With(
 		slog.String("op", op),
 		slog.Int64("user ID", userID),
 	)
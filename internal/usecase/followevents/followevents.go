@@ -0,0 +1,81 @@
+package followevents
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"love-signal-users/internal/dto"
+	"love-signal-users/internal/entity"
+	"love-signal-users/internal/infrastructure"
+	"love-signal-users/internal/usecase"
+	"love-signal-users/pkg/eventbus"
+	"love-signal-users/pkg/logger"
+	"love-signal-users/pkg/logger/sl"
+	"love-signal-users/pkg/tracing"
+)
+
+// Repository is a repository for follow events use-case.
+type Repository interface {
+	User(ctx context.Context, id int64) (dto.User, error)
+}
+
+// Subscriber subscribes to follow events of the user.
+type Subscriber interface {
+	Subscribe(userID int64) (*eventbus.Subscription[entity.FollowEvent], error)
+}
+
+// UseCase is a use-case for subscribing to follow events.
+type UseCase struct {
+	log        *slog.Logger
+	repo       Repository
+	subscriber Subscriber
+}
+
+// New returns new follow events use-case.
+func New(log *slog.Logger, repo Repository, subscriber Subscriber) *UseCase {
+	return &UseCase{
+		log:        log,
+		repo:       repo,
+		subscriber: subscriber,
+	}
+}
+
+// Execute executes the use-case for subscribing to follow events of the user.
+func (uc *UseCase) Execute(
+	ctx context.Context,
+	userID int64,
+) (_ *eventbus.Subscription[entity.FollowEvent], err error) {
+	const op = "usecase.followevents.Execute"
+
+	ctx, span := tracing.Start(ctx, op, slog.Int64("user ID", userID))
+	defer tracing.Finish(span, &err)
+
+	log := logger.FromContext(ctx, uc.log).With(
+		slog.String("op", op),
+		slog.Int64("user ID", userID),
+	)
+
+	if _, err := uc.repo.User(ctx, userID); err != nil {
+		if errors.Is(err, infrastructure.ErrEntityNotFound) {
+			log.Warn("user not found", sl.Err(err))
+
+			return nil, fmt.Errorf("%s: %w", op, usecase.ErrUserNotFound)
+		}
+
+		log.Error("error getting user data by user ID", sl.Err(err))
+
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	subscription, err := uc.subscriber.Subscribe(userID)
+	if err != nil {
+		log.Error("error subscribing to follow events", sl.Err(err))
+
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("subscribed to follow events")
+
+	return subscription, nil
+}
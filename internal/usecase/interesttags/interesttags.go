@@ -0,0 +1,50 @@
+package interesttags
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"love-signal-users/pkg/logger"
+	"love-signal-users/pkg/logger/sl"
+	"love-signal-users/pkg/tracing"
+)
+
+// Repository is a repository for interest tags use-case.
+type Repository interface {
+	InterestTags(ctx context.Context) ([]string, error)
+}
+
+// UseCase is a use-case for getting the dictionary of interest tags.
+type UseCase struct {
+	log  *slog.Logger
+	repo Repository
+}
+
+// New returns new interest tags use-case.
+func New(log *slog.Logger, repo Repository) *UseCase {
+	return &UseCase{
+		log:  log,
+		repo: repo,
+	}
+}
+
+// Execute executes the use-case for getting the names of the interest tags users can choose from.
+func (uc *UseCase) Execute(ctx context.Context) (_ []string, err error) {
+	const op = "usecase.interesttags.Execute"
+
+	ctx, span := tracing.Start(ctx, op)
+	defer tracing.Finish(span, &err)
+
+	log := logger.FromContext(ctx, uc.log).With(
+		slog.String("op", op),
+	)
+
+	tags, err := uc.repo.InterestTags(ctx)
+	if err != nil {
+		log.Error("error getting interest tags", sl.Err(err))
+
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return tags, nil
+}
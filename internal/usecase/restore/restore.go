@@ -0,0 +1,82 @@
+package restore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"love-signal-users/internal/entity"
+	"love-signal-users/internal/enum"
+	"love-signal-users/internal/infrastructure"
+	"love-signal-users/internal/usecase"
+	"love-signal-users/pkg/caller"
+	"love-signal-users/pkg/logger"
+	"love-signal-users/pkg/logger/sl"
+	"love-signal-users/pkg/tracing"
+)
+
+// Repository is a repository for restore user use-case.
+type Repository interface {
+	SetUserDeleted(ctx context.Context, id int64, deleted bool) error
+}
+
+// Auditor records state-changing calls to the audit log.
+type Auditor interface {
+	Audit(ctx context.Context, record entity.AuditRecord)
+}
+
+// UseCase is a use-case for restoring deactivated users.
+type UseCase struct {
+	log     *slog.Logger
+	repo    Repository
+	auditor Auditor
+}
+
+// New returns new restore user use-case.
+func New(log *slog.Logger, repo Repository, auditor Auditor) *UseCase {
+	return &UseCase{
+		log:     log,
+		repo:    repo,
+		auditor: auditor,
+	}
+}
+
+// Execute executes the use-case for restoring deactivated user.
+// Restoring a user that is not deactivated has no effect.
+func (uc *UseCase) Execute(ctx context.Context, userID int64) (err error) {
+	const op = "usecase.restore.Execute"
+
+	ctx, span := tracing.Start(ctx, op, slog.Int64("user ID", userID))
+	defer tracing.Finish(span, &err)
+
+	log := logger.FromContext(ctx, uc.log).With(
+		slog.String("op", op),
+		slog.Int64("user ID", userID),
+	)
+
+	// The action is taken by an operator, who is identified by the caller of the admin call.
+	defer func() {
+		uc.auditor.Audit(ctx, entity.NewAuditRecord(
+			enum.AuditRestore,
+			entity.OperatorRef(caller.FromContext(ctx)),
+			entity.UserRef(userID),
+			err,
+		))
+	}()
+
+	if err = uc.repo.SetUserDeleted(ctx, userID, false); err != nil {
+		if errors.Is(err, infrastructure.ErrEntityNotFound) {
+			log.Warn("user not found", sl.Err(err))
+
+			return fmt.Errorf("%s: %w", op, usecase.ErrUserNotFound)
+		}
+
+		log.Error("error restoring user", sl.Err(err))
+
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("user restored")
+
+	return nil
+}
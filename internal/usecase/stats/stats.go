@@ -0,0 +1,52 @@
+package stats
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"love-signal-users/internal/dto"
+	"love-signal-users/internal/entity"
+	"love-signal-users/pkg/logger"
+	"love-signal-users/pkg/logger/sl"
+	"love-signal-users/pkg/tracing"
+)
+
+// Repository is a repository for stats use-case.
+type Repository interface {
+	Stats(ctx context.Context) (dto.Stats, error)
+}
+
+// UseCase is a use-case for getting the numbers of users, follows and likes and the size of the storage.
+type UseCase struct {
+	log  *slog.Logger
+	repo Repository
+}
+
+// New returns new stats use-case.
+func New(log *slog.Logger, repo Repository) *UseCase {
+	return &UseCase{
+		log:  log,
+		repo: repo,
+	}
+}
+
+// Execute executes the use-case for getting stats.
+func (uc *UseCase) Execute(ctx context.Context) (_ entity.Stats, err error) {
+	const op = "usecase.stats.Execute"
+
+	ctx, span := tracing.Start(ctx, op)
+	defer tracing.Finish(span, &err)
+
+	log := logger.FromContext(ctx, uc.log).With(
+		slog.String("op", op),
+	)
+
+	stats, err := uc.repo.Stats(ctx)
+	if err != nil {
+		log.Error("error getting stats", sl.Err(err))
+
+		return entity.Stats{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return entity.NewStats(stats), nil
+}
@@ -2,50 +2,103 @@ package unfollow
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"love-signal-users/internal/dto"
 	"love-signal-users/internal/entity"
+	"love-signal-users/internal/enum"
+	"love-signal-users/internal/infrastructure"
+	"love-signal-users/internal/usecase"
+	"love-signal-users/pkg/logger"
 	"love-signal-users/pkg/logger/sl"
+	"love-signal-users/pkg/tracing"
 )
 
 // Repository is a repository for unfollow user use-case.
 type Repository interface {
+	Follow(ctx context.Context, followLinkID int64) (dto.Follow, error)
 	SaveFollow(ctx context.Context, follow *entity.Follow) error
 }
 
+// Publisher publishes follow events to the user.
+type Publisher interface {
+	Publish(userID int64, event entity.FollowEvent)
+}
+
+// Auditor records state-changing calls to the audit log.
+type Auditor interface {
+	Audit(ctx context.Context, record entity.AuditRecord)
+}
+
 // UseCase is a use-case for unfollowing users.
 type UseCase struct {
-	log  *slog.Logger
-	repo Repository
+	log       *slog.Logger
+	repo      Repository
+	publisher Publisher
+	auditor   Auditor
 }
 
 // New returns new unfollow user use-case.
-func New(log *slog.Logger, repo Repository) *UseCase {
+func New(log *slog.Logger, repo Repository, publisher Publisher, auditor Auditor) *UseCase {
 	return &UseCase{
-		log:  log,
-		repo: repo,
+		log:       log,
+		repo:      repo,
+		publisher: publisher,
+		auditor:   auditor,
 	}
 }
 
 // Execute executes the use-case for unfollowing user.
-func (uc *UseCase) Execute(ctx context.Context, followLinkID int64) error {
+func (uc *UseCase) Execute(ctx context.Context, followLinkID int64) (err error) {
 	const op = "usecase.unfollow.Execute"
 
-	log := uc.log.With(
+	ctx, span := tracing.Start(ctx, op, slog.Int64("follow link ID", followLinkID))
+	defer tracing.Finish(span, &err)
+
+	log := logger.FromContext(ctx, uc.log).With(
 		slog.String("op", op),
 		slog.Int64("follow link ID", followLinkID),
 	)
 
-	followDTO := dto.Follow{ID: followLinkID}
+	// The actor is known when the follow is found.
+	var actor string
+	defer func() {
+		uc.auditor.Audit(ctx, entity.NewAuditRecord(enum.AuditUnfollow, actor, entity.FollowRef(followLinkID), err))
+	}()
+
+	followDTO, err := uc.repo.Follow(ctx, followLinkID)
+	if err != nil {
+		if errors.Is(err, infrastructure.ErrEntityNotFound) {
+			log.Warn("follow not found", sl.Err(err))
+
+			return fmt.Errorf("%s: %w", op, usecase.ErrFollowNotFound)
+		}
+
+		log.Error("error getting follow", sl.Err(err))
+
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
 	followEntity := entity.NewFollow(followDTO)
+	actor = entity.UserRef(followEntity.FollowingUser.ID)
 	followEntity.SetToRemove()
 
-	if err := uc.repo.SaveFollow(ctx, &followEntity); err != nil {
+	if err = uc.repo.SaveFollow(ctx, &followEntity); err != nil {
 		log.Error("error saving follow", sl.Err(err))
 
 		return fmt.Errorf("%s: %w", op, err)
 	}
 
+	uc.publisher.Publish(
+		followEntity.FollowedUser.ID,
+		entity.NewFollowEvent(
+			enum.Unfollowed,
+			followEntity.FollowedUser.ID,
+			followEntity.FollowingUser.ID,
+			followEntity.ID,
+		),
+	)
+
 	return nil
 }
@@ -0,0 +1,179 @@
+package updateprofile
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"love-signal-users/internal/dto"
+	"love-signal-users/internal/entity"
+	"love-signal-users/internal/enum"
+	"love-signal-users/internal/infrastructure"
+	"love-signal-users/internal/usecase"
+	"love-signal-users/pkg/logger"
+	"love-signal-users/pkg/logger/sl"
+	"love-signal-users/pkg/tracing"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+// Repository is a repository for update user profile use-case.
+type Repository interface {
+	User(ctx context.Context, id int64) (dto.User, error)
+	InterestTags(ctx context.Context) ([]string, error)
+	UpdateUserProfile(ctx context.Context, user entity.User) error
+}
+
+// Auditor records state-changing calls to the audit log.
+type Auditor interface {
+	Audit(ctx context.Context, record entity.AuditRecord)
+}
+
+// UseCase is a use-case for updating the extended profile of users.
+type UseCase struct {
+	log     *slog.Logger
+	repo    Repository
+	auditor Auditor
+}
+
+// New returns new update user profile use-case.
+func New(log *slog.Logger, repo Repository, auditor Auditor) *UseCase {
+	return &UseCase{
+		log:     log,
+		repo:    repo,
+		auditor: auditor,
+	}
+}
+
+// Execute executes the use-case for updating the extended profile of the user and returns the updated user.
+// The profile is replaced as a whole. Bio and city are trimmed; interests are matched to the dictionary
+// of interest tags case-insensitively.
+func (uc *UseCase) Execute(ctx context.Context, userID int64, profile dto.UserProfile) (_ entity.User, err error) {
+	const op = "usecase.updateprofile.Execute"
+
+	ctx, span := tracing.Start(ctx, op, slog.Int64("user ID", userID))
+	defer tracing.Finish(span, &err)
+
+	log := logger.FromContext(ctx, uc.log).With(
+		slog.String("op", op),
+		slog.Int64("user ID", userID),
+	)
+
+	defer func() {
+		uc.auditor.Audit(
+			ctx,
+			entity.NewAuditRecord(enum.AuditUpdateProfile, entity.UserRef(userID), entity.UserRef(userID), err),
+		)
+	}()
+
+	profile, err = uc.normalize(ctx, profile)
+	if err != nil {
+		var invalid *usecase.InvalidProfileError
+		if errors.As(err, &invalid) {
+			log.Warn("invalid profile", sl.Err(err))
+		} else {
+			log.Error("error validating profile", sl.Err(err))
+		}
+
+		return entity.User{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	user, err := uc.repo.User(ctx, userID)
+	if err != nil {
+		if errors.Is(err, infrastructure.ErrEntityNotFound) {
+			log.Warn("user not found", sl.Err(err))
+
+			return entity.User{}, fmt.Errorf("%s: %w", op, usecase.ErrUserNotFound)
+		}
+
+		log.Error("error getting user", sl.Err(err))
+
+		return entity.User{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	userEntity := entity.NewUser(user)
+	userEntity.SetProfile(profile)
+
+	if err = uc.repo.UpdateUserProfile(ctx, userEntity); err != nil {
+		if errors.Is(err, infrastructure.ErrEntityNotFound) {
+			log.Warn("user not found", sl.Err(err))
+
+			return entity.User{}, fmt.Errorf("%s: %w", op, usecase.ErrUserNotFound)
+		}
+
+		log.Error("error updating user profile", sl.Err(err))
+
+		return entity.User{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("user profile updated", slog.Int("interests", len(profile.Interests)))
+
+	return userEntity, nil
+}
+
+// normalize returns the profile with bio and city trimmed, empty ones cleared, and the interests deduplicated,
+// in lower case and in order of name. All the problems of an invalid profile are returned together.
+func (uc *UseCase) normalize(ctx context.Context, profile dto.UserProfile) (dto.UserProfile, error) {
+	var problems []string
+
+	profile.Bio = trimmed(profile.Bio)
+	if profile.Bio != nil && utf8.RuneCountInString(*profile.Bio) > entity.MaxBioLength {
+		problems = append(problems, fmt.Sprintf("bio is longer than %d characters", entity.MaxBioLength))
+	}
+
+	profile.City = trimmed(profile.City)
+	if profile.City != nil && utf8.RuneCountInString(*profile.City) > entity.MaxCityLength {
+		problems = append(problems, fmt.Sprintf("city is longer than %d characters", entity.MaxCityLength))
+	}
+
+	interests := make([]string, 0, len(profile.Interests))
+	for _, interest := range profile.Interests {
+		interest = strings.ToLower(strings.TrimSpace(interest))
+		if !slices.Contains(interests, interest) {
+			interests = append(interests, interest)
+		}
+	}
+	slices.Sort(interests)
+	profile.Interests = interests
+
+	if len(interests) > entity.MaxInterests {
+		problems = append(problems, fmt.Sprintf("more than %d interests", entity.MaxInterests))
+	}
+
+	if len(interests) > 0 {
+		tags, err := uc.repo.InterestTags(ctx)
+		if err != nil {
+			return dto.UserProfile{}, err
+		}
+
+		var unknown []string
+		for _, interest := range interests {
+			if !slices.Contains(tags, interest) {
+				unknown = append(unknown, fmt.Sprintf("%q", interest))
+			}
+		}
+		if len(unknown) > 0 {
+			problems = append(problems, "unknown interests "+strings.Join(unknown, ", "))
+		}
+	}
+
+	if len(problems) > 0 {
+		return dto.UserProfile{}, &usecase.InvalidProfileError{Problems: problems}
+	}
+
+	return profile, nil
+}
+
+func trimmed(value *string) *string {
+	if value == nil {
+		return nil
+	}
+
+	s := strings.TrimSpace(*value)
+	if s == "" {
+		return nil
+	}
+
+	return &s
+}
@@ -0,0 +1,177 @@
+package updateprofile
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"love-signal-users/internal/dto"
+	"love-signal-users/internal/entity"
+	"love-signal-users/internal/usecase"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// fakeRepository is a repository with the dictionary of interest tags only.
+type fakeRepository struct {
+	tags          []string
+	tagsErr       error
+	interestCalls int
+}
+
+func (r *fakeRepository) User(context.Context, int64) (dto.User, error) {
+	return dto.User{}, errors.New("not implemented")
+}
+
+func (r *fakeRepository) InterestTags(context.Context) ([]string, error) {
+	r.interestCalls++
+
+	return r.tags, r.tagsErr
+}
+
+func (r *fakeRepository) UpdateUserProfile(context.Context, entity.User) error {
+	return errors.New("not implemented")
+}
+
+func ptr(s string) *string {
+	return &s
+}
+
+func Test_Normalize(t *testing.T) {
+	tags := []string{"art", "books", "cooking", "dancing", "fashion", "fitness", "gaming", "hiking", "movies", "music",
+		"pets"}
+
+	tests := []struct {
+		name     string
+		profile  dto.UserProfile
+		expected dto.UserProfile
+		problems []string
+	}{
+		{
+			name:     "empty profile",
+			profile:  dto.UserProfile{},
+			expected: dto.UserProfile{Interests: []string{}},
+		},
+		{
+			name:     "bio and city trimmed",
+			profile:  dto.UserProfile{Bio: ptr("  Hello  "), City: ptr("\tParis\n")},
+			expected: dto.UserProfile{Bio: ptr("Hello"), City: ptr("Paris"), Interests: []string{}},
+		},
+		{
+			name:     "blank bio and city cleared",
+			profile:  dto.UserProfile{Bio: ptr("   "), City: ptr("")},
+			expected: dto.UserProfile{Interests: []string{}},
+		},
+		{
+			name:     "interests deduplicated in lower case and in order of name",
+			profile:  dto.UserProfile{Interests: []string{" Music", "art", "MUSIC", "art "}},
+			expected: dto.UserProfile{Interests: []string{"art", "music"}},
+		},
+		{
+			name:    "bio and city of max length in characters",
+			profile: dto.UserProfile{Bio: ptr(strings.Repeat("é", 500)), City: ptr(strings.Repeat("ü", 100))},
+			expected: dto.UserProfile{
+				Bio:       ptr(strings.Repeat("é", 500)),
+				City:      ptr(strings.Repeat("ü", 100)),
+				Interests: []string{},
+			},
+		},
+		{
+			name:     "bio too long",
+			profile:  dto.UserProfile{Bio: ptr(strings.Repeat("é", 501))},
+			problems: []string{"bio is longer than 500 characters"},
+		},
+		{
+			name:     "city too long",
+			profile:  dto.UserProfile{City: ptr(strings.Repeat("ü", 101))},
+			problems: []string{"city is longer than 100 characters"},
+		},
+		{
+			name:     "too many interests",
+			profile:  dto.UserProfile{Interests: tags},
+			problems: []string{"more than 10 interests"},
+		},
+		{
+			name:     "unknown interests",
+			profile:  dto.UserProfile{Interests: []string{"art", "Knitting", "chess"}},
+			problems: []string{`unknown interests "chess", "knitting"`},
+		},
+		{
+			name: "all problems together",
+			profile: dto.UserProfile{
+				Bio:       ptr(strings.Repeat("a", 501)),
+				City:      ptr(strings.Repeat("a", 101)),
+				Interests: []string{"chess"},
+			},
+			problems: []string{
+				"bio is longer than 500 characters",
+				"city is longer than 100 characters",
+				`unknown interests "chess"`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := New(slog.New(slog.DiscardHandler), &fakeRepository{tags: tags}, nil)
+
+			profile, err := uc.normalize(context.Background(), tt.profile)
+			if tt.problems != nil {
+				var invalid *usecase.InvalidProfileError
+				if !errors.As(err, &invalid) {
+					t.Fatalf("expected invalid profile error, got: %v", err)
+				}
+				if !slices.Equal(invalid.Problems, tt.problems) {
+					t.Errorf("expected problems %q, got: %q", tt.problems, invalid.Problems)
+				}
+
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if !equalPtr(profile.Bio, tt.expected.Bio) || !equalPtr(profile.City, tt.expected.City) {
+				t.Errorf("expected bio %v and city %v, got: %v and %v",
+					deref(tt.expected.Bio), deref(tt.expected.City), deref(profile.Bio), deref(profile.City))
+			}
+			if profile.Interests == nil || !slices.Equal(profile.Interests, tt.expected.Interests) {
+				t.Errorf("expected interests %q, got: %q", tt.expected.Interests, profile.Interests)
+			}
+		})
+	}
+}
+
+func Test_NormalizeLooksUpTagsOnlyForInterests(t *testing.T) {
+	repo := &fakeRepository{tagsErr: errors.New("storage is down")}
+	uc := New(slog.New(slog.DiscardHandler), repo, nil)
+
+	if _, err := uc.normalize(context.Background(), dto.UserProfile{Bio: ptr("Hello")}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if repo.interestCalls != 0 {
+		t.Errorf("expected no interest tags lookup without interests, got: %d", repo.interestCalls)
+	}
+
+	_, err := uc.normalize(context.Background(), dto.UserProfile{Interests: []string{"art"}})
+	var invalid *usecase.InvalidProfileError
+	if !errors.Is(err, repo.tagsErr) || errors.As(err, &invalid) {
+		t.Errorf("expected error of interest tags lookup, got: %v", err)
+	}
+}
+
+func equalPtr(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	return *a == *b
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return "<nil>"
+	}
+
+	return *s
+}
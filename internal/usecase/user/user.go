@@ -9,7 +9,9 @@ import (
 	"love-signal-users/internal/entity"
 	"love-signal-users/internal/infrastructure"
 	"love-signal-users/internal/usecase"
+	"love-signal-users/pkg/logger"
 	"love-signal-users/pkg/logger/sl"
+	"love-signal-users/pkg/tracing"
 )
 
 // Repository is a repository for user data use-case.
@@ -32,10 +34,13 @@ func New(log *slog.Logger, repo Repository) *UseCase {
 }
 
 // Execute executes the use-case for getting user data.
-func (uc *UseCase) Execute(ctx context.Context, id int64) (entity.User, error) {
+func (uc *UseCase) Execute(ctx context.Context, id int64) (_ entity.User, err error) {
 	const op = "usecase.user.Execute"
 
-	log := uc.log.With(
+	ctx, span := tracing.Start(ctx, op, slog.Int64("user ID", id))
+	defer tracing.Finish(span, &err)
+
+	log := logger.FromContext(ctx, uc.log).With(
 		slog.String("op", op),
 		slog.Int64("user ID", id),
 	)
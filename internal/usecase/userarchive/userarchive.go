@@ -0,0 +1,123 @@
+package userarchive
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"love-signal-users/internal/dto"
+	"love-signal-users/internal/entity"
+	"love-signal-users/internal/enum"
+	"love-signal-users/internal/infrastructure"
+	"love-signal-users/internal/usecase"
+	"love-signal-users/pkg/caller"
+	"love-signal-users/pkg/logger"
+	"love-signal-users/pkg/logger/sl"
+	"love-signal-users/pkg/tracing"
+	"time"
+)
+
+// Repository is a repository for user archive use-case.
+type Repository interface {
+	ArchivedUser(ctx context.Context, externalID int64) (dto.ArchivedUser, error)
+	ArchivedFollows(ctx context.Context, userID int64) ([]dto.ArchivedFollow, error)
+	UserInterests(ctx context.Context, userID int64) ([]string, error)
+}
+
+// History returns the audit records of the calls involving the users and the follow links with the refs.
+type History interface {
+	Records(ctx context.Context, refs []string) ([]dto.HistoryRecord, error)
+}
+
+// Auditor records state-changing calls to the audit log.
+type Auditor interface {
+	Audit(ctx context.Context, record entity.AuditRecord)
+}
+
+// UseCase is a use-case for building the archive of everything stored about a user.
+type UseCase struct {
+	log     *slog.Logger
+	repo    Repository
+	history History
+	auditor Auditor
+}
+
+// New returns new user archive use-case.
+func New(log *slog.Logger, repo Repository, history History, auditor Auditor) *UseCase {
+	return &UseCase{
+		log:     log,
+		repo:    repo,
+		history: history,
+		auditor: auditor,
+	}
+}
+
+// Execute executes the use-case for building the archive of the user with the external ID.
+// Deleted users are archived too. The history includes the previous exports and the records
+// of the user's current follow links; records of links removed since are found only when the user is their actor.
+// The access to the personal data is audited.
+func (uc *UseCase) Execute(ctx context.Context, externalID int64) (_ entity.UserArchive, err error) {
+	const op = "usecase.userarchive.Execute"
+
+	ctx, span := tracing.Start(ctx, op, slog.Int64("user external ID", externalID))
+	defer tracing.Finish(span, &err)
+
+	log := logger.FromContext(ctx, uc.log).With(
+		slog.String("op", op),
+		slog.Int64("user external ID", externalID),
+	)
+
+	// The data are accessed by an operator, who is identified by the caller of the admin call.
+	defer func() {
+		uc.auditor.Audit(ctx, entity.NewAuditRecord(
+			enum.AuditExportData,
+			entity.OperatorRef(caller.FromContext(ctx)),
+			entity.ExternalUserRef(externalID),
+			err,
+		))
+	}()
+
+	user, err := uc.repo.ArchivedUser(ctx, externalID)
+	if err != nil {
+		if errors.Is(err, infrastructure.ErrEntityNotFound) {
+			log.Warn("user not found", sl.Err(err))
+
+			return entity.UserArchive{}, fmt.Errorf("%s: %w", op, usecase.ErrUserNotFound)
+		}
+
+		log.Error("error getting user", sl.Err(err))
+
+		return entity.UserArchive{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	user.Interests, err = uc.repo.UserInterests(ctx, user.ID)
+	if err != nil {
+		log.Error("error getting interests", sl.Err(err))
+
+		return entity.UserArchive{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	follows, err := uc.repo.ArchivedFollows(ctx, user.ID)
+	if err != nil {
+		log.Error("error getting follows", sl.Err(err))
+
+		return entity.UserArchive{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	refs := make([]string, 0, len(follows)+2)
+	refs = append(refs, entity.UserRef(user.ID), entity.ExternalUserRef(externalID))
+	for _, follow := range follows {
+		refs = append(refs, entity.FollowRef(follow.ID))
+	}
+
+	history, err := uc.history.Records(ctx, refs)
+	if err != nil {
+		log.Error("error getting history", sl.Err(err))
+
+		return entity.UserArchive{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("user data archived", slog.Int("follows", len(follows)), slog.Int("history records", len(history)))
+
+	return entity.NewUserArchive(user, follows, history, time.Now()), nil
+}
@@ -0,0 +1,123 @@
+package userarchive
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"love-signal-users/internal/dto"
+	"love-signal-users/internal/entity"
+	"love-signal-users/internal/enum"
+	"love-signal-users/internal/infrastructure"
+	"love-signal-users/internal/usecase"
+	"love-signal-users/pkg/caller"
+	"slices"
+	"testing"
+)
+
+// fakeRepository is a repository with one user and their follow links.
+type fakeRepository struct {
+	user    dto.ArchivedUser
+	follows []dto.ArchivedFollow
+}
+
+func (r *fakeRepository) ArchivedUser(_ context.Context, externalID int64) (dto.ArchivedUser, error) {
+	if externalID != r.user.ExternalID {
+		return dto.ArchivedUser{}, fmt.Errorf("repository.users.ArchivedUser: %w", infrastructure.ErrEntityNotFound)
+	}
+
+	return r.user, nil
+}
+
+func (r *fakeRepository) ArchivedFollows(context.Context, int64) ([]dto.ArchivedFollow, error) {
+	return r.follows, nil
+}
+
+func (r *fakeRepository) UserInterests(context.Context, int64) ([]string, error) {
+	return []string{"music"}, nil
+}
+
+// fakeHistory returns the records whose actor or target is one of the refs, as the audit log history does.
+type fakeHistory struct {
+	records []dto.HistoryRecord
+}
+
+func (h *fakeHistory) Records(_ context.Context, refs []string) ([]dto.HistoryRecord, error) {
+	records := make([]dto.HistoryRecord, 0)
+	for _, rec := range h.records {
+		if slices.Contains(refs, rec.Actor) || slices.Contains(refs, rec.Target) {
+			records = append(records, rec)
+		}
+	}
+
+	return records, nil
+}
+
+type fakeAuditor struct {
+	records []entity.AuditRecord
+}
+
+func (a *fakeAuditor) Audit(_ context.Context, record entity.AuditRecord) {
+	a.records = append(a.records, record)
+}
+
+func newTestUseCase() (*UseCase, *fakeAuditor) {
+	repo := &fakeRepository{
+		user: dto.ArchivedUser{User: dto.User{ID: 1, ExternalID: 101}},
+		follows: []dto.ArchivedFollow{
+			{ID: 10, FollowingExternalID: 101, FollowedExternalID: 102},
+			{ID: 11, FollowingExternalID: 103, FollowedExternalID: 101, NumberOfLikes: 2},
+		},
+	}
+	history := &fakeHistory{records: []dto.HistoryRecord{
+		{Action: string(enum.AuditFollow), Actor: entity.UserRef(1), Target: entity.UserRef(2)},
+		{Action: string(enum.AuditUnfollow), Actor: entity.UserRef(3), Target: entity.FollowRef(11)},
+		{Action: string(enum.AuditExportData), Target: entity.ExternalUserRef(101)},
+		{Action: string(enum.AuditFollow), Actor: entity.UserRef(3), Target: entity.UserRef(2)},
+	}}
+	auditor := &fakeAuditor{}
+
+	return New(slog.New(slog.DiscardHandler), repo, history, auditor), auditor
+}
+
+func Test_ExecuteSplitsFollowsAndMatchesHistory(t *testing.T) {
+	uc, auditor := newTestUseCase()
+
+	archive, err := uc.Execute(caller.ContextWithCaller(context.Background(), "operator-cert"), 101)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(archive.Following) != 1 || archive.Following[0].UserExternalID != 102 {
+		t.Errorf("expected following user 102, got: %+v", archive.Following)
+	}
+	if len(archive.Followers) != 1 || archive.Followers[0].UserExternalID != 103 || archive.Followers[0].NumberOfLikes != 2 {
+		t.Errorf("expected follower 103 with 2 likes, got: %+v", archive.Followers)
+	}
+	if !slices.Equal(archive.Interests, []string{"music"}) {
+		t.Errorf("expected interests of the user, got: %v", archive.Interests)
+	}
+
+	// The records of the user, their external ID and their follow links match; the last record does not.
+	if len(archive.History) != 3 {
+		t.Errorf("expected 3 history records of the user, got: %+v", archive.History)
+	}
+
+	if len(auditor.records) != 1 || auditor.records[0].Action != enum.AuditExportData ||
+		auditor.records[0].Actor != entity.OperatorRef("operator-cert") ||
+		auditor.records[0].Target != entity.ExternalUserRef(101) {
+		t.Errorf("expected export audited, got: %+v", auditor.records)
+	}
+}
+
+func Test_ExecuteMapsNotFound(t *testing.T) {
+	uc, auditor := newTestUseCase()
+
+	_, err := uc.Execute(context.Background(), 999)
+	if !errors.Is(err, usecase.ErrUserNotFound) {
+		t.Errorf("expected error `%v`, got: %v", usecase.ErrUserNotFound, err)
+	}
+	if len(auditor.records) != 1 || !errors.Is(auditor.records[0].Err, usecase.ErrUserNotFound) {
+		t.Errorf("expected failed export audited, got: %+v", auditor.records)
+	}
+}
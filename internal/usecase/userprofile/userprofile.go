@@ -0,0 +1,62 @@
+package userprofile
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"love-signal-users/internal/dto"
+	"love-signal-users/internal/entity"
+	"love-signal-users/internal/infrastructure"
+	"love-signal-users/internal/usecase"
+	"love-signal-users/pkg/logger"
+	"love-signal-users/pkg/logger/sl"
+	"love-signal-users/pkg/tracing"
+)
+
+// Repository is a repository for user profile use-case.
+type Repository interface {
+	UserWithInterests(ctx context.Context, id int64) (dto.User, error)
+}
+
+// UseCase is a use-case for getting user data with the extended profile.
+type UseCase struct {
+	log  *slog.Logger
+	repo Repository
+}
+
+// New returns new user profile use-case.
+func New(log *slog.Logger, repo Repository) *UseCase {
+	return &UseCase{
+		log:  log,
+		repo: repo,
+	}
+}
+
+// Execute executes the use-case for getting user data with the extended profile: bio, city and interests.
+func (uc *UseCase) Execute(ctx context.Context, id int64) (_ entity.User, err error) {
+	const op = "usecase.userprofile.Execute"
+
+	ctx, span := tracing.Start(ctx, op, slog.Int64("user ID", id))
+	defer tracing.Finish(span, &err)
+
+	log := logger.FromContext(ctx, uc.log).With(
+		slog.String("op", op),
+		slog.Int64("user ID", id),
+	)
+
+	user, err := uc.repo.UserWithInterests(ctx, id)
+	if err != nil {
+		if errors.Is(err, infrastructure.ErrEntityNotFound) {
+			log.Warn("user not found", sl.Err(err))
+
+			return entity.User{}, fmt.Errorf("%s: %w", op, usecase.ErrUserNotFound)
+		}
+
+		log.Error("error getting user profile", sl.Err(err))
+
+		return entity.User{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return entity.NewUser(user), nil
+}
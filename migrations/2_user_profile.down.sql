@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS ix_user_interests_tag_id;
+DROP TABLE IF EXISTS user_interests;
+DROP INDEX IF EXISTS ix_interest_tags_name;
+DROP TABLE IF EXISTS interest_tags;
+ALTER TABLE users DROP COLUMN city;
+ALTER TABLE users DROP COLUMN bio;
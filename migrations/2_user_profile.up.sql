@@ -0,0 +1,39 @@
+ALTER TABLE users ADD COLUMN bio text CHECK (length(bio) <= 500);
+ALTER TABLE users ADD COLUMN city varchar(100) CHECK (length(city) <= 100);
+
+CREATE TABLE IF NOT EXISTS interest_tags (
+  id integer NOT NULL PRIMARY KEY AUTOINCREMENT,
+  name varchar(50) NOT NULL
+);
+CREATE UNIQUE INDEX IF NOT EXISTS ix_interest_tags_name ON interest_tags (name);
+
+INSERT INTO interest_tags (name) VALUES
+  ('art'),
+  ('books'),
+  ('cooking'),
+  ('dancing'),
+  ('fashion'),
+  ('fitness'),
+  ('gaming'),
+  ('hiking'),
+  ('movies'),
+  ('music'),
+  ('pets'),
+  ('photography'),
+  ('sports'),
+  ('technology'),
+  ('theatre'),
+  ('travel'),
+  ('volunteering'),
+  ('yoga');
+
+CREATE TABLE IF NOT EXISTS user_interests
+(
+  user_id integer NOT NULL,
+  tag_id integer NOT NULL,
+  created_at timestamp NOT NULL,
+  PRIMARY KEY (user_id, tag_id),
+  FOREIGN KEY (user_id)  REFERENCES users (id),
+  FOREIGN KEY (tag_id)  REFERENCES interest_tags (id)
+);
+CREATE INDEX IF NOT EXISTS ix_user_interests_tag_id ON user_interests (tag_id);
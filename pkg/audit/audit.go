@@ -0,0 +1,240 @@
+package audit
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+// Results of the audited calls.
+const (
+	ResultSuccess = "success"
+	ResultFailure = "failure"
+)
+
+// maxLineSize is the max size of a record line read from the audit log.
+const maxLineSize = 1024 * 1024
+
+var (
+	// ErrChainBroken is returned when a record of the audit log does not match the hash chain.
+	ErrChainBroken = errors.New("audit log hash chain is broken")
+	// ErrClosed is returned when a record is written to a closed audit log.
+	ErrClosed = errors.New("audit log is closed")
+)
+
+// Record is a record of the audit log.
+type Record struct {
+	Time      time.Time `json:"time"`
+	Action    string    `json:"action"`
+	Actor     string    `json:"actor"`
+	Caller    string    `json:"caller"`
+	Target    string    `json:"target"`
+	Result    string    `json:"result"`
+	Error     string    `json:"error,omitempty"`
+	RequestID string    `json:"requestId,omitempty"`
+
+	// PrevHash is the hash of the previous record, empty for the first record.
+	PrevHash string `json:"prevHash"`
+	// Hash is the hash of the previous hash and this record without the hash.
+	Hash string `json:"hash"`
+}
+
+// Log is an append-only audit log file of JSON lines. Records are chained by hashes,
+// so a changed, removed or inserted record breaks the chain and is detected by Verify.
+type Log struct {
+	mutex    sync.Mutex
+	file     *os.File
+	lastHash string
+}
+
+// Open opens the audit log file for appending, creating it if it does not exist.
+// The chain continues from the last record of the file.
+func Open(path string) (*Log, error) {
+	const op = "audit.Open"
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_RDWR, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	lastHash, err := lastRecordHash(file)
+	if err != nil {
+		_ = file.Close()
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &Log{
+		file:     file,
+		lastHash: lastHash,
+	}, nil
+}
+
+// Write appends the record to the audit log and syncs the file.
+func (l *Log) Write(rec Record) error {
+	const op = "audit.Write"
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if l.file == nil {
+		return fmt.Errorf("%s: %w", op, ErrClosed)
+	}
+
+	rec.PrevHash = l.lastHash
+	hash, err := recordHash(rec)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	rec.Hash = hash
+
+	line, err := json.Marshal(rec)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if _, err = l.file.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if err = l.file.Sync(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	l.lastHash = hash
+
+	return nil
+}
+
+// Close closes the audit log file.
+func (l *Log) Close() error {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if l.file == nil {
+		return nil
+	}
+
+	err := l.file.Close()
+	l.file = nil
+
+	return err
+}
+
+// Verify reads the audit log and checks the hash chain of its records. Blank lines are skipped.
+// It returns the number of verified records, and ErrChainBroken with the line of the first broken record.
+func Verify(r io.Reader) (int, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLineSize)
+
+	var prevHash string
+	line, records := 0, 0
+	for scanner.Scan() {
+		line++
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+
+		var rec Record
+		if err := json.Unmarshal(scanner.Bytes(), &rec); err != nil {
+			return records, fmt.Errorf("line %d: %w: %w", line, ErrChainBroken, err)
+		}
+
+		if rec.PrevHash != prevHash {
+			return records, fmt.Errorf("line %d: %w: previous hash does not match", line, ErrChainBroken)
+		}
+
+		hash, err := recordHash(rec)
+		if err != nil {
+			return records, fmt.Errorf("line %d: %w", line, err)
+		}
+		if rec.Hash != hash {
+			return records, fmt.Errorf("line %d: %w: record hash does not match", line, ErrChainBroken)
+		}
+
+		prevHash = rec.Hash
+		records++
+	}
+
+	if err := scanner.Err(); err != nil {
+		return records, err
+	}
+
+	return records, nil
+}
+
+// Read reads the records of the audit log in order and calls fn for each, stopping at the first error.
+// The hash chain is not checked; use Verify for that.
+func Read(r io.Reader, fn func(Record) error) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLineSize)
+
+	line := 0
+	for scanner.Scan() {
+		line++
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+
+		var rec Record
+		if err := json.Unmarshal(scanner.Bytes(), &rec); err != nil {
+			return fmt.Errorf("line %d: %w", line, err)
+		}
+
+		if err := fn(rec); err != nil {
+			return err
+		}
+	}
+
+	return scanner.Err()
+}
+
+// recordHash returns the hash of the record with its previous hash and without its hash.
+func recordHash(rec Record) (string, error) {
+	rec.Hash = ""
+
+	data, err := json.Marshal(rec)
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256(data)
+
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// lastRecordHash returns the hash of the last record of the file, or empty string if the file is empty.
+func lastRecordHash(file *os.File) (string, error) {
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return "", err
+	}
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLineSize)
+
+	var last []byte
+	for scanner.Scan() {
+		if len(scanner.Bytes()) > 0 {
+			last = append(last[:0], scanner.Bytes()...)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return "", err
+	}
+
+	if last == nil {
+		return "", nil
+	}
+
+	var rec Record
+	if err := json.Unmarshal(last, &rec); err != nil {
+		return "", fmt.Errorf("last record: %w", err)
+	}
+
+	return rec.Hash, nil
+}
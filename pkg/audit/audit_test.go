@@ -0,0 +1,89 @@
+package audit
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func Test_LogChainsRecordsAcrossReopen(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+
+	for _, action := range []string{"follow", "unfollow"} {
+		auditLog, err := Open(path)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		err = auditLog.Write(Record{Time: time.Now(), Action: action, Actor: "user:1", Target: "user:2", Result: ResultSuccess})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if err = auditLog.Close(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	n, err := Verify(bytes.NewReader(data))
+	if err != nil || n != 2 {
+		t.Fatalf("expected 2 verified records, got: %d, %v", n, err)
+	}
+
+	withBlankLines := append(bytes.Replace(data, []byte("\n"), []byte("\n\n"), 1), '\n')
+	if n, err = Verify(bytes.NewReader(withBlankLines)); err != nil || n != 2 {
+		t.Fatalf("expected 2 verified records with blank lines skipped, got: %d, %v", n, err)
+	}
+
+	tampered := bytes.Replace(data, []byte(`"user:2"`), []byte(`"user:3"`), 1)
+	if _, err = Verify(bytes.NewReader(tampered)); !errors.Is(err, ErrChainBroken) {
+		t.Errorf("expected broken chain of the changed record, got: %v", err)
+	}
+
+	lines := bytes.SplitAfter(data, []byte("\n"))
+	if _, err = Verify(bytes.NewReader(lines[1])); !errors.Is(err, ErrChainBroken) {
+		t.Errorf("expected broken chain of the removed record, got: %v", err)
+	}
+}
+
+func Test_ReadReturnsRecordsInOrder(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+
+	auditLog, err := Open(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, target := range []string{"user:2", "user:3"} {
+		if err = auditLog.Write(Record{Time: time.Now(), Action: "follow", Actor: "user:1", Target: target}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if err = auditLog.Close(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	var targets []string
+	err = Read(file, func(rec Record) error {
+		targets = append(targets, rec.Target)
+
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(targets) != 2 || targets[0] != "user:2" || targets[1] != "user:3" {
+		t.Errorf("expected targets user:2 and user:3, got: %v", targets)
+	}
+}
@@ -0,0 +1,24 @@
+// Package caller carries the identity of the caller of a request in its context, whatever the transport.
+package caller
+
+import "context"
+
+// Unknown is the caller of requests that came with no caller in the context.
+const Unknown = "unknown"
+
+type contextKey struct{}
+
+// ContextWithCaller returns a copy of the context with the caller of the request.
+func ContextWithCaller(ctx context.Context, caller string) context.Context {
+	return context.WithValue(ctx, contextKey{}, caller)
+}
+
+// FromContext returns the caller of the request from the context, or Unknown if there is none.
+func FromContext(ctx context.Context) string {
+	caller, _ := ctx.Value(contextKey{}).(string)
+	if caller == "" {
+		return Unknown
+	}
+
+	return caller
+}
@@ -0,0 +1,136 @@
+package eventbus
+
+import (
+	"errors"
+	"sync"
+)
+
+const defaultBufferSize = 64
+
+var (
+	// ErrClosed is returned when the bus is closed.
+	ErrClosed = errors.New("event bus is closed")
+	// ErrSlowSubscriber is the reason the subscription is dropped when its buffer is full.
+	ErrSlowSubscriber = errors.New("subscriber does not keep up with events")
+)
+
+// Bus is an in-process event bus. Events are published to subscribers of the key.
+// Publishing never blocks: a subscriber whose buffer is full is dropped.
+type Bus[K comparable, E any] struct {
+	mutex         sync.Mutex
+	subscriptions map[K]map[*Subscription[E]]struct{}
+	bufferSize    int
+	closed        bool
+}
+
+// Subscription is a subscription to the events of the key.
+type Subscription[E any] struct {
+	events      chan E
+	err         error
+	unsubscribe func()
+}
+
+// New returns new event bus. Each subscriber buffers up to bufferSize events.
+func New[K comparable, E any](bufferSize int) *Bus[K, E] {
+	if bufferSize <= 0 {
+		bufferSize = defaultBufferSize
+	}
+
+	return &Bus[K, E]{
+		subscriptions: make(map[K]map[*Subscription[E]]struct{}),
+		bufferSize:    bufferSize,
+	}
+}
+
+// Subscribe subscribes to the events of the key.
+func (b *Bus[K, E]) Subscribe(key K) (*Subscription[E], error) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if b.closed {
+		return nil, ErrClosed
+	}
+
+	sub := &Subscription[E]{
+		events: make(chan E, b.bufferSize),
+	}
+	sub.unsubscribe = func() {
+		b.mutex.Lock()
+		defer b.mutex.Unlock()
+
+		b.remove(key, sub, nil)
+	}
+
+	if _, ok := b.subscriptions[key]; !ok {
+		b.subscriptions[key] = make(map[*Subscription[E]]struct{})
+	}
+	b.subscriptions[key][sub] = struct{}{}
+
+	return sub, nil
+}
+
+// Publish publishes the event to the subscribers of the key.
+func (b *Bus[K, E]) Publish(key K, event E) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	for sub := range b.subscriptions[key] {
+		select {
+		case sub.events <- event:
+		default:
+			b.remove(key, sub, ErrSlowSubscriber)
+		}
+	}
+}
+
+// Close closes the bus and ends all subscriptions.
+func (b *Bus[K, E]) Close() {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if b.closed {
+		return
+	}
+	b.closed = true
+
+	for key, subs := range b.subscriptions {
+		for sub := range subs {
+			b.remove(key, sub, ErrClosed)
+		}
+	}
+}
+
+// remove removes the subscription and closes its events channel. Must be called with the mutex held.
+func (b *Bus[K, E]) remove(key K, sub *Subscription[E], reason error) {
+	subs, ok := b.subscriptions[key]
+	if !ok {
+		return
+	}
+	if _, ok = subs[sub]; !ok {
+		return
+	}
+
+	delete(subs, sub)
+	if len(subs) == 0 {
+		delete(b.subscriptions, key)
+	}
+
+	sub.err = reason
+	close(sub.events)
+}
+
+// Events returns the channel of events. The channel is closed when the subscription ends.
+func (s *Subscription[E]) Events() <-chan E {
+	return s.events
+}
+
+// Err returns the reason the subscription has ended: ErrSlowSubscriber, ErrClosed,
+// or nil if it was unsubscribed. Must be called after the events channel is closed.
+func (s *Subscription[E]) Err() error {
+	return s.err
+}
+
+// Unsubscribe ends the subscription.
+func (s *Subscription[E]) Unsubscribe() {
+	s.unsubscribe()
+}
@@ -0,0 +1,63 @@
+package eventbus
+
+import (
+	"errors"
+	"testing"
+)
+
+func Test_PublishesToSubscribersOfKey(t *testing.T) {
+	bus := New[int64, string](1)
+
+	sub, err := bus.Subscribe(1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	other, err := bus.Subscribe(2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	bus.Publish(1, "followed")
+
+	if event := <-sub.Events(); event != "followed" {
+		t.Errorf("expected `followed` event, got: `%s`", event)
+	}
+	if len(other.Events()) != 0 {
+		t.Error("expected no events for subscriber of another key")
+	}
+}
+
+func Test_DropsSlowSubscriber(t *testing.T) {
+	bus := New[int64, string](1)
+
+	sub, _ := bus.Subscribe(1)
+	bus.Publish(1, "first")
+	bus.Publish(1, "second")
+
+	<-sub.Events()
+	if _, ok := <-sub.Events(); ok {
+		t.Fatal("expected events channel to be closed")
+	}
+	if !errors.Is(sub.Err(), ErrSlowSubscriber) {
+		t.Errorf("expected error %v, got: %v", ErrSlowSubscriber, sub.Err())
+	}
+}
+
+func Test_CloseEndsSubscriptions(t *testing.T) {
+	bus := New[int64, string](1)
+
+	sub, _ := bus.Subscribe(1)
+	bus.Close()
+
+	if _, ok := <-sub.Events(); ok {
+		t.Fatal("expected events channel to be closed")
+	}
+	if !errors.Is(sub.Err(), ErrClosed) {
+		t.Errorf("expected error %v, got: %v", ErrClosed, sub.Err())
+	}
+	if _, err := bus.Subscribe(1); !errors.Is(err, ErrClosed) {
+		t.Errorf("expected error %v, got: %v", ErrClosed, err)
+	}
+
+	sub.Unsubscribe()
+}
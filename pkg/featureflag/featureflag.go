@@ -0,0 +1,143 @@
+// Package featureflag turns features on for all users, a share of users or listed users.
+// Flags are replaced at runtime; each call reads the flags it started with from its context.
+package featureflag
+
+import (
+	"context"
+	"hash/fnv"
+	"math"
+	"strconv"
+	"sync/atomic"
+)
+
+// buckets is the number of buckets users are split into for percentage rollouts: a bucket is 0.01%.
+const buckets = 10000
+
+// Flag is the configuration of a feature flag.
+// The flag is on for all users if it is enabled, otherwise for the percentage of users, from 0 to 100,
+// and for the users with the external IDs of the allow-list. A percentage that is not a number is 0.
+type Flag struct {
+	Enabled    bool
+	Percentage float64
+	AllowList  []int64
+}
+
+// User is the user a flag is checked for. Zero IDs are unknown: a user without ID is not in percentage
+// rollouts below 100%, a user without external ID is not in allow-lists.
+type User struct {
+	ID         int64
+	ExternalID int64
+}
+
+// flag is a feature flag ready for checks.
+type flag struct {
+	enabled   bool
+	threshold uint64
+	allowList map[int64]struct{}
+}
+
+// Flags are the feature flags at a point in time. Nil flags are all off.
+type Flags struct {
+	flags map[string]flag
+}
+
+// NewFlags returns the flags of the configurations by name.
+func NewFlags(flags map[string]Flag) *Flags {
+	f := &Flags{flags: make(map[string]flag, len(flags))}
+	for name, cfg := range flags {
+		allowList := make(map[int64]struct{}, len(cfg.AllowList))
+		for _, externalID := range cfg.AllowList {
+			allowList[externalID] = struct{}{}
+		}
+
+		percentage := cfg.Percentage
+		if math.IsNaN(percentage) {
+			percentage = 0
+		}
+
+		f.flags[name] = flag{
+			enabled:   cfg.Enabled,
+			threshold: uint64(min(max(percentage, 0), 100) * buckets / 100),
+			allowList: allowList,
+		}
+	}
+
+	return f
+}
+
+// Enabled reports whether the flag is on for the user. Unknown flags are off.
+// A user stays in the rollout of a flag as its percentage grows; rollouts of different flags are independent.
+func (f *Flags) Enabled(name string, user User) bool {
+	if f == nil {
+		return false
+	}
+
+	fl, ok := f.flags[name]
+	if !ok {
+		return false
+	}
+
+	if fl.enabled || fl.threshold >= buckets {
+		return true
+	}
+	if _, ok = fl.allowList[user.ExternalID]; ok && user.ExternalID != 0 {
+		return true
+	}
+	if user.ID == 0 {
+		return false
+	}
+
+	return bucket(name, user.ID) < fl.threshold
+}
+
+// bucket returns the bucket of the user in the rollout of the flag.
+func bucket(name string, userID int64) uint64 {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(name))
+	_, _ = h.Write([]byte{':'})
+	_, _ = h.Write(strconv.AppendInt(nil, userID, 10))
+
+	return h.Sum64() % buckets
+}
+
+// Registry holds the current flags.
+type Registry struct {
+	current atomic.Pointer[Flags]
+}
+
+// NewRegistry returns new registry of the flags.
+func NewRegistry(flags map[string]Flag) *Registry {
+	r := &Registry{}
+	r.Set(flags)
+
+	return r
+}
+
+// Set replaces the flags. Calls already running keep the flags they started with.
+func (r *Registry) Set(flags map[string]Flag) {
+	r.current.Store(NewFlags(flags))
+}
+
+// Current returns the current flags.
+func (r *Registry) Current() *Flags {
+	return r.current.Load()
+}
+
+type contextKey struct{}
+
+// ContextWithFlags returns a copy of the context with the flags.
+func ContextWithFlags(ctx context.Context, flags *Flags) context.Context {
+	return context.WithValue(ctx, contextKey{}, flags)
+}
+
+// FromContext returns the flags from the context, or nil flags, all off, if there are none.
+func FromContext(ctx context.Context) *Flags {
+	flags, _ := ctx.Value(contextKey{}).(*Flags)
+
+	return flags
+}
+
+// Enabled reports whether the flag from the context is on for the user.
+func Enabled(ctx context.Context, name string, user User) bool {
+	return FromContext(ctx).Enabled(name, user)
+}
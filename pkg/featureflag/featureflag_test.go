@@ -0,0 +1,71 @@
+package featureflag
+
+import (
+	"context"
+	"math"
+	"testing"
+)
+
+func Test_EnabledByFlagAllowListAndRollout(t *testing.T) {
+	flags := NewFlags(map[string]Flag{
+		"on":       {Enabled: true},
+		"dark":     {AllowList: []int64{101}},
+		"rollout":  {Percentage: 30},
+		"everyone": {Percentage: 100},
+		"nan":      {Percentage: math.NaN()},
+	})
+
+	if !flags.Enabled("on", User{}) {
+		t.Error("expected enabled flag to be on for unknown user")
+	}
+	if flags.Enabled("missing", User{ID: 1, ExternalID: 101}) {
+		t.Error("expected unknown flag to be off")
+	}
+	if !flags.Enabled("dark", User{ExternalID: 101}) || flags.Enabled("dark", User{ID: 1, ExternalID: 102}) {
+		t.Error("expected dark flag to be on only for allow-listed user")
+	}
+	if flags.Enabled("nan", User{ID: 1}) {
+		t.Error("expected rollout of percentage that is not a number to be off")
+	}
+	if !flags.Enabled("everyone", User{}) {
+		t.Error("expected 100% rollout to be on for unknown user")
+	}
+
+	on := 0
+	for id := int64(1); id <= 10000; id++ {
+		if flags.Enabled("rollout", User{ID: id}) {
+			on++
+		}
+	}
+	if on < 2700 || on > 3300 {
+		t.Errorf("expected about 30%% of users in rollout, got: %d of 10000", on)
+	}
+}
+
+func Test_RolloutKeepsUsersAsPercentageGrows(t *testing.T) {
+	small := NewFlags(map[string]Flag{"rollout": {Percentage: 10}})
+	large := NewFlags(map[string]Flag{"rollout": {Percentage: 50}})
+
+	for id := int64(1); id <= 1000; id++ {
+		if small.Enabled("rollout", User{ID: id}) && !large.Enabled("rollout", User{ID: id}) {
+			t.Fatalf("expected user %d to stay in rollout", id)
+		}
+	}
+}
+
+func Test_ContextKeepsFlagsAfterSet(t *testing.T) {
+	registry := NewRegistry(map[string]Flag{"feature": {Enabled: true}})
+	ctx := ContextWithFlags(context.Background(), registry.Current())
+
+	registry.Set(nil)
+
+	if !Enabled(ctx, "feature", User{}) {
+		t.Error("expected flags of the context to be kept")
+	}
+	if registry.Current().Enabled("feature", User{}) {
+		t.Error("expected current flags to be replaced")
+	}
+	if Enabled(context.Background(), "feature", User{}) {
+		t.Error("expected flags to be off without flags in context")
+	}
+}
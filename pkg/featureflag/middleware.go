@@ -0,0 +1,41 @@
+package featureflag
+
+import (
+	"context"
+	"google.golang.org/grpc"
+	"net/http"
+)
+
+// UnaryServerInterceptor returns a unary server interceptor that stores the current flags in the context of the call.
+func (r *Registry) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		return handler(ContextWithFlags(ctx, r.Current()), req)
+	}
+}
+
+// StreamServerInterceptor returns a stream server interceptor that stores the current flags in the context
+// of the stream. Long-lived streams keep the flags they started with.
+func (r *Registry) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx := ContextWithFlags(ss.Context(), r.Current())
+
+		return handler(srv, &contextServerStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+// Middleware returns an HTTP handler that stores the current flags in the context of the request.
+func (r *Registry) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		next.ServeHTTP(w, req.WithContext(ContextWithFlags(req.Context(), r.Current())))
+	})
+}
+
+// contextServerStream is a server stream with the context replaced.
+type contextServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *contextServerStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,74 @@
+package featureflag
+
+import (
+	"context"
+	"google.golang.org/grpc"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// fakeServerStream is a server stream with the context only.
+type fakeServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *fakeServerStream) Context() context.Context {
+	return s.ctx
+}
+
+func Test_InterceptorsStoreCurrentFlags(t *testing.T) {
+	registry := NewRegistry(map[string]Flag{"feature": {}})
+
+	calls := map[string]func() *Flags{
+		"unary": func() *Flags {
+			var flags *Flags
+			_, _ = registry.UnaryServerInterceptor()(context.Background(), nil, &grpc.UnaryServerInfo{},
+				func(ctx context.Context, _ any) (any, error) {
+					flags = FromContext(ctx)
+
+					return nil, nil
+				},
+			)
+
+			return flags
+		},
+		"stream": func() *Flags {
+			var flags *Flags
+			_ = registry.StreamServerInterceptor()(nil, &fakeServerStream{ctx: context.Background()},
+				&grpc.StreamServerInfo{},
+				func(_ any, ss grpc.ServerStream) error {
+					flags = FromContext(ss.Context())
+
+					return nil
+				},
+			)
+
+			return flags
+		},
+		"http": func() *Flags {
+			var flags *Flags
+			handler := registry.Middleware(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+				flags = FromContext(r.Context())
+			}))
+			handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+
+			return flags
+		},
+	}
+
+	for name, call := range calls {
+		t.Run(name, func(t *testing.T) {
+			registry.Set(map[string]Flag{"feature": {}})
+			if flags := call(); flags == nil || flags.Enabled("feature", User{}) {
+				t.Error("expected flag off before it is set")
+			}
+
+			registry.Set(map[string]Flag{"feature": {Enabled: true}})
+			if flags := call(); !flags.Enabled("feature", User{}) {
+				t.Error("expected flag on in calls after it is set")
+			}
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package grpcserver
+
+import (
+	"context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"slices"
+	"strings"
+)
+
+// allowedCallers rejects calls of the service from callers that are not allowed.
+type allowedCallers struct {
+	prefix  string
+	callers []string
+}
+
+func (a *allowedCallers) check(ctx context.Context, fullMethod string) error {
+	if !strings.HasPrefix(fullMethod, a.prefix) {
+		return nil
+	}
+
+	if slices.Contains(a.callers, CallerKey(ctx)) {
+		return nil
+	}
+
+	return status.Error(codes.PermissionDenied, "caller is not allowed to call the service")
+}
+
+func (a *allowedCallers) unaryServerInterceptor(
+	ctx context.Context,
+	req any,
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (any, error) {
+	if err := a.check(ctx, info.FullMethod); err != nil {
+		return nil, err
+	}
+
+	return handler(ctx, req)
+}
+
+func (a *allowedCallers) streamServerInterceptor(
+	srv any,
+	ss grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) error {
+	if err := a.check(ss.Context(), info.FullMethod); err != nil {
+		return err
+	}
+
+	return handler(srv, ss)
+}
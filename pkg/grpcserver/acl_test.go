@@ -0,0 +1,33 @@
+package grpcserver
+
+import (
+	"context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"testing"
+)
+
+func Test_AllowedCallersRestrictOnlyTheService(t *testing.T) {
+	acl := &allowedCallers{prefix: "/admin.Admin/", callers: []string{"operator"}}
+	handler := func(ctx context.Context, req any) (any, error) { return req, nil }
+
+	call := func(caller, method string) error {
+		ctx := ContextWithCaller(context.Background(), caller)
+		_, err := acl.unaryServerInterceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: method}, handler)
+
+		return err
+	}
+
+	if err := call("operator", "/admin.Admin/GetStats"); err != nil {
+		t.Errorf("expected allowed caller to call the service, got: %v", err)
+	}
+
+	if err := call("alice", "/admin.Admin/GetStats"); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("expected code %s, got: %v", codes.PermissionDenied, err)
+	}
+
+	if err := call("alice", getUserMethod); err != nil {
+		t.Errorf("expected other services not to be restricted, got: %v", err)
+	}
+}
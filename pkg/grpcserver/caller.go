@@ -0,0 +1,58 @@
+package grpcserver
+
+import (
+	"context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/peer"
+	"love-signal-users/pkg/caller"
+	"net"
+)
+
+type callerCtxKey struct{}
+
+// ContextWithCaller returns a copy of the context with the identity of the authenticated caller.
+func ContextWithCaller(ctx context.Context, identity string) context.Context {
+	return context.WithValue(ctx, callerCtxKey{}, identity)
+}
+
+// CallerFromContext returns the identity of the authenticated caller stored in the context.
+func CallerFromContext(ctx context.Context) (string, bool) {
+	identity, ok := ctx.Value(callerCtxKey{}).(string)
+	if !ok || identity == "" {
+		return "", false
+	}
+
+	return identity, true
+}
+
+// CallerKey returns a key identifying the caller of the request.
+// The identity of the authenticated caller is used if present, otherwise the peer address without a port.
+func CallerKey(ctx context.Context) string {
+	if identity, ok := CallerFromContext(ctx); ok {
+		return identity
+	}
+
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return caller.Unknown
+	}
+
+	address := p.Addr.String()
+	if host, _, err := net.SplitHostPort(address); err == nil {
+		return host
+	}
+
+	return address
+}
+
+// callerUnaryInterceptor stores the caller key in the context of the call with the caller package.
+func callerUnaryInterceptor(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	return handler(caller.ContextWithCaller(ctx, CallerKey(ctx)), req)
+}
+
+// callerStreamInterceptor stores the caller key in the context of the stream with the caller package.
+func callerStreamInterceptor(srv any, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx := caller.ContextWithCaller(ss.Context(), CallerKey(ss.Context()))
+
+	return handler(srv, &contextServerStream{ServerStream: ss, ctx: ctx})
+}
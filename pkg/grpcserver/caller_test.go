@@ -0,0 +1,52 @@
+package grpcserver
+
+import (
+	"context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/peer"
+	"love-signal-users/pkg/caller"
+	"net"
+	"testing"
+)
+
+func Test_CallerInterceptorStoresCallerKey(t *testing.T) {
+	var got string
+	handler := func(ctx context.Context, _ any) (any, error) {
+		got = caller.FromContext(ctx)
+
+		return nil, nil
+	}
+
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		expected string
+	}{
+		{
+			name:     "client identity",
+			ctx:      ContextWithCaller(context.Background(), "operator"),
+			expected: "operator",
+		},
+		{
+			name: "peer address",
+			ctx: peer.NewContext(context.Background(), &peer.Peer{
+				Addr: &net.TCPAddr{IP: net.IPv4(10, 0, 0, 7), Port: 51234},
+			}),
+			expected: "10.0.0.7",
+		},
+		{
+			name:     "no peer",
+			ctx:      context.Background(),
+			expected: caller.Unknown,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _ = callerUnaryInterceptor(tt.ctx, nil, &grpc.UnaryServerInfo{}, handler)
+			if got != tt.expected {
+				t.Errorf("expected caller %q, got: %q", tt.expected, got)
+			}
+		})
+	}
+}
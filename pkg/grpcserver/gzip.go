@@ -0,0 +1,40 @@
+package grpcserver
+
+import (
+	"context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/encoding/gzip"
+	"slices"
+)
+
+func gzipUnaryInterceptor(
+	ctx context.Context,
+	req any,
+	_ *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (any, error) {
+	setGzipCompressor(ctx)
+
+	return handler(ctx, req)
+}
+
+func gzipStreamInterceptor(
+	srv any,
+	ss grpc.ServerStream,
+	_ *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) error {
+	setGzipCompressor(ss.Context())
+
+	return handler(srv, ss)
+}
+
+// setGzipCompressor compresses the response with gzip if the client supports it.
+func setGzipCompressor(ctx context.Context) {
+	compressors, err := grpc.ClientSupportedCompressors(ctx)
+	if err != nil || !slices.Contains(compressors, gzip.Name) {
+		return
+	}
+
+	_ = grpc.SetSendCompressor(ctx, gzip.Name)
+}
@@ -1,6 +1,15 @@
 package grpcserver
 
-import "net"
+import (
+	"crypto/tls"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/keepalive"
+	"google.golang.org/grpc/stats"
+	"log/slog"
+	"net"
+	"time"
+)
 
 // Option is how options for the Server are set up.
 type Option func(*Server)
@@ -8,6 +17,154 @@ type Option func(*Server)
 // WithPort sets up a port for gRPC server.
 func WithPort(port string) Option {
 	return func(s *Server) {
-		s.address = net.JoinHostPort("", port)
+		host, _, _ := net.SplitHostPort(s.address)
+		s.address = net.JoinHostPort(host, port)
+	}
+}
+
+// WithHost sets up a host to bind the gRPC server to. The server binds to all interfaces by default.
+func WithHost(host string) Option {
+	return func(s *Server) {
+		_, port, _ := net.SplitHostPort(s.address)
+		s.address = net.JoinHostPort(host, port)
+	}
+}
+
+// WithUnixSocket sets up the gRPC server to listen on a Unix domain socket instead of TCP.
+func WithUnixSocket(path string) Option {
+	return func(s *Server) {
+		s.unixSocket = path
+	}
+}
+
+// WithKeepaliveParams sets up keepalive and max-age parameters for the gRPC server.
+func WithKeepaliveParams(params keepalive.ServerParameters) Option {
+	return func(s *Server) {
+		s.keepaliveParams = params
+	}
+}
+
+// WithKeepaliveEnforcementPolicy sets up keepalive enforcement policy for the gRPC server.
+// Clients that ping more often than the policy allows are disconnected.
+func WithKeepaliveEnforcementPolicy(policy keepalive.EnforcementPolicy) Option {
+	return func(s *Server) {
+		s.serverOptions = append(s.serverOptions, grpc.KeepaliveEnforcementPolicy(policy))
+	}
+}
+
+// WithConnectionIdleTimeout sets up how long an idle connection is kept open.
+// It takes precedence over MaxConnectionIdle of the keepalive parameters.
+func WithConnectionIdleTimeout(timeout time.Duration) Option {
+	return func(s *Server) {
+		s.connectionIdleTimeout = timeout
+	}
+}
+
+// WithMaxRecvMsgSize sets up the max message size in bytes the gRPC server can receive.
+func WithMaxRecvMsgSize(size int) Option {
+	return func(s *Server) {
+		s.serverOptions = append(s.serverOptions, grpc.MaxRecvMsgSize(size))
+	}
+}
+
+// WithMaxSendMsgSize sets up the max message size in bytes the gRPC server can send.
+func WithMaxSendMsgSize(size int) Option {
+	return func(s *Server) {
+		s.serverOptions = append(s.serverOptions, grpc.MaxSendMsgSize(size))
+	}
+}
+
+// WithMaxConcurrentStreams sets up the max number of concurrent streams for each connection.
+func WithMaxConcurrentStreams(streams uint32) Option {
+	return func(s *Server) {
+		s.serverOptions = append(s.serverOptions, grpc.MaxConcurrentStreams(streams))
+	}
+}
+
+// WithGzip sets up gzip compression of responses for clients that support it.
+func WithGzip() Option {
+	return func(s *Server) {
+		s.unaryInterceptors = append(s.unaryInterceptors, gzipUnaryInterceptor)
+		s.streamInterceptors = append(s.streamInterceptors, gzipStreamInterceptor)
+	}
+}
+
+// WithDrainTimeout sets up how long Stop waits for active calls before stopping the server hard.
+// Stop waits without limit by default.
+func WithDrainTimeout(timeout time.Duration) Option {
+	return func(s *Server) {
+		s.drainTimeout = timeout
+	}
+}
+
+// WithUnaryInterceptors adds unary interceptors to the gRPC server.
+// Interceptors are called in the order they were added.
+func WithUnaryInterceptors(interceptors ...grpc.UnaryServerInterceptor) Option {
+	return func(s *Server) {
+		s.unaryInterceptors = append(s.unaryInterceptors, interceptors...)
+	}
+}
+
+// WithStreamInterceptors adds stream interceptors to the gRPC server.
+// Interceptors are called in the order they were added.
+func WithStreamInterceptors(interceptors ...grpc.StreamServerInterceptor) Option {
+	return func(s *Server) {
+		s.streamInterceptors = append(s.streamInterceptors, interceptors...)
+	}
+}
+
+// WithRateLimiter sets up a rate limiter for all unary and stream calls of the gRPC server.
+func WithRateLimiter(limiter *RateLimiter) Option {
+	return func(s *Server) {
+		s.unaryInterceptors = append(s.unaryInterceptors, limiter.UnaryServerInterceptor())
+		s.streamInterceptors = append(s.streamInterceptors, limiter.StreamServerInterceptor())
+	}
+}
+
+// WithTLSConfig sets up TLS for the gRPC server.
+// The identity of a verified client certificate is stored in the request context as the caller identity,
+// so this option should be set before options that use the caller identity.
+func WithTLSConfig(cfg *tls.Config) Option {
+	return func(s *Server) {
+		s.serverOptions = append(s.serverOptions, grpc.Creds(credentials.NewTLS(cfg)))
+		s.unaryInterceptors = append(s.unaryInterceptors, clientIdentityUnaryInterceptor)
+		s.streamInterceptors = append(s.streamInterceptors, clientIdentityStreamInterceptor)
+	}
+}
+
+// WithStatsHandler sets up a stats handler for the gRPC server, e.g. to trace calls.
+func WithStatsHandler(handler stats.Handler) Option {
+	return func(s *Server) {
+		s.serverOptions = append(s.serverOptions, grpc.StatsHandler(handler))
+	}
+}
+
+// WithRequestLogger sets up request IDs for all unary and stream calls of the gRPC server.
+// The request ID is taken from the incoming metadata or generated, echoed back in the response header,
+// and the request-scoped logger with it is stored in the request context.
+// This option should be set before options that reject calls, so that rejected calls have the request ID too.
+func WithRequestLogger(log *slog.Logger) Option {
+	return func(s *Server) {
+		s.unaryInterceptors = append(s.unaryInterceptors, requestLoggerUnaryInterceptor(log))
+		s.streamInterceptors = append(s.streamInterceptors, requestLoggerStreamInterceptor(log))
+	}
+}
+
+// WithTimeouts sets up deadlines of unary calls of the gRPC server.
+// Stream calls are not limited, as they are long-lived.
+func WithTimeouts(timeouts *Timeouts) Option {
+	return func(s *Server) {
+		s.unaryInterceptors = append(s.unaryInterceptors, timeouts.UnaryServerInterceptor())
+	}
+}
+
+// WithAllowedCallers restricts calls of the service (e.g. "admin.Admin") to the callers, which are matched by CallerKey:
+// identities of verified client certificates or peer addresses. Without callers, the service cannot be called.
+// This option should be set after WithTLSConfig, so that the caller identity is known.
+func WithAllowedCallers(service string, callers []string) Option {
+	return func(s *Server) {
+		acl := &allowedCallers{prefix: "/" + service + "/", callers: callers}
+		s.unaryInterceptors = append(s.unaryInterceptors, acl.unaryServerInterceptor)
+		s.streamInterceptors = append(s.streamInterceptors, acl.streamServerInterceptor)
 	}
 }
@@ -0,0 +1,225 @@
+package grpcserver
+
+import (
+	"context"
+	"golang.org/x/time/rate"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// RetryAfterHeader is the metadata key with the number of seconds after which a rejected call can be retried.
+	RetryAfterHeader = "retry-after"
+
+	defaultBucketTTL = 10 * time.Minute
+)
+
+// Limit is a token bucket limit: RPS tokens are added per second up to Burst tokens.
+// A limit with non-positive RPS does not restrict calls.
+type Limit struct {
+	RPS   float64
+	Burst int
+}
+
+func (l Limit) unlimited() bool {
+	return l.RPS <= 0
+}
+
+// KeyFunc returns a key of the caller for which a separate token bucket is kept.
+type KeyFunc func(ctx context.Context) string
+
+// RateLimiter limits calls of the gRPC server per method and per caller.
+type RateLimiter struct {
+	mutex        sync.Mutex
+	defaultLimit Limit
+	methodLimits map[string]Limit
+	buckets      map[bucketKey]*bucket
+	lastSweep    time.Time
+
+	keyFunc   KeyFunc
+	bucketTTL time.Duration
+	now       func() time.Time
+}
+
+type bucketKey struct {
+	method string
+	caller string
+}
+
+type bucket struct {
+	limit    Limit
+	limiter  *rate.Limiter
+	lastSeen time.Time
+}
+
+// RateLimiterOption is how options for the RateLimiter are set up.
+type RateLimiterOption func(*RateLimiter)
+
+// WithKeyFunc sets up a function for getting a caller key. CallerKey is used by default.
+func WithKeyFunc(keyFunc KeyFunc) RateLimiterOption {
+	return func(rl *RateLimiter) {
+		rl.keyFunc = keyFunc
+	}
+}
+
+// WithBucketTTL sets up how long the token bucket of an idle caller is kept.
+func WithBucketTTL(ttl time.Duration) RateLimiterOption {
+	return func(rl *RateLimiter) {
+		rl.bucketTTL = ttl
+	}
+}
+
+// NewRateLimiter returns new rate limiter.
+// Method limits are keyed by full method name (e.g. "/users.Users/FollowUser") or by bare method name
+// (e.g. "FollowUser"). Methods without own limit use the default limit.
+func NewRateLimiter(defaultLimit Limit, methodLimits map[string]Limit, opts ...RateLimiterOption) *RateLimiter {
+	rl := &RateLimiter{
+		buckets:   make(map[bucketKey]*bucket),
+		keyFunc:   CallerKey,
+		bucketTTL: defaultBucketTTL,
+		now:       time.Now,
+	}
+	rl.SetLimits(defaultLimit, methodLimits)
+
+	for _, opt := range opts {
+		opt(rl)
+	}
+
+	rl.lastSweep = rl.now()
+
+	return rl
+}
+
+// SetLimits replaces the limits of the rate limiter. Token buckets with changed limits are recreated.
+func (rl *RateLimiter) SetLimits(defaultLimit Limit, methodLimits map[string]Limit) {
+	limits := make(map[string]Limit, len(methodLimits))
+	for method, limit := range methodLimits {
+		limits[method] = limit
+	}
+
+	rl.mutex.Lock()
+	defer rl.mutex.Unlock()
+
+	rl.defaultLimit = defaultLimit
+	rl.methodLimits = limits
+}
+
+// UnaryServerInterceptor returns a unary server interceptor that rejects calls exceeding the limits.
+func (rl *RateLimiter) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req any,
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (any, error) {
+		if err := rl.check(ctx, info.FullMethod); err != nil {
+			return nil, err
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor returns a stream server interceptor that rejects calls exceeding the limits.
+func (rl *RateLimiter) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(
+		srv any,
+		ss grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		if err := rl.check(ss.Context(), info.FullMethod); err != nil {
+			return err
+		}
+
+		return handler(srv, ss)
+	}
+}
+
+func (rl *RateLimiter) check(ctx context.Context, fullMethod string) error {
+	retryAfter, ok := rl.allow(fullMethod, rl.keyFunc(ctx))
+	if ok {
+		return nil
+	}
+
+	seconds := int64(math.Ceil(retryAfter.Seconds()))
+	if seconds < 1 {
+		seconds = 1
+	}
+
+	_ = grpc.SetHeader(ctx, metadata.Pairs(RetryAfterHeader, strconv.FormatInt(seconds, 10)))
+
+	return status.Errorf(codes.ResourceExhausted, "rate limit exceeded, retry after %d s", seconds)
+}
+
+// allow takes a token from the bucket of the caller for the method.
+// If there is no token, it returns the time after which the token will be available.
+func (rl *RateLimiter) allow(fullMethod, caller string) (time.Duration, bool) {
+	rl.mutex.Lock()
+	defer rl.mutex.Unlock()
+
+	now := rl.now()
+	rl.sweep(now)
+
+	limit := rl.limitFor(fullMethod)
+	if limit.unlimited() {
+		return 0, true
+	}
+
+	key := bucketKey{method: fullMethod, caller: caller}
+	b, ok := rl.buckets[key]
+	if !ok || b.limit != limit {
+		b = &bucket{
+			limit:   limit,
+			limiter: rate.NewLimiter(rate.Limit(limit.RPS), max(limit.Burst, 1)),
+		}
+		rl.buckets[key] = b
+	}
+	b.lastSeen = now
+
+	if b.limiter.AllowN(now, 1) {
+		return 0, true
+	}
+
+	missing := 1 - b.limiter.TokensAt(now)
+
+	return time.Duration(missing / limit.RPS * float64(time.Second)), false
+}
+
+func (rl *RateLimiter) limitFor(fullMethod string) Limit {
+	if limit, ok := rl.methodLimits[fullMethod]; ok {
+		return limit
+	}
+
+	if limit, ok := rl.methodLimits[methodName(fullMethod)]; ok {
+		return limit
+	}
+
+	return rl.defaultLimit
+}
+
+// methodName returns the bare method name of the full method name, e.g. "FollowUser" of "/users.Users/FollowUser".
+func methodName(fullMethod string) string {
+	return fullMethod[strings.LastIndex(fullMethod, "/")+1:]
+}
+
+// sweep removes the token buckets of idle callers.
+func (rl *RateLimiter) sweep(now time.Time) {
+	if now.Sub(rl.lastSweep) < rl.bucketTTL {
+		return
+	}
+
+	for key, b := range rl.buckets {
+		if now.Sub(b.lastSeen) >= rl.bucketTTL {
+			delete(rl.buckets, key)
+		}
+	}
+	rl.lastSweep = now
+}
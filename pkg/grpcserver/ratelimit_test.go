@@ -0,0 +1,87 @@
+package grpcserver
+
+import (
+	"context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"testing"
+	"time"
+)
+
+const (
+	followMethod  = "/users.Users/FollowUser"
+	getUserMethod = "/users.Users/GetUserData"
+)
+
+func newTestRateLimiter(now *time.Time) *RateLimiter {
+	rl := NewRateLimiter(
+		Limit{RPS: 10, Burst: 10},
+		map[string]Limit{"FollowUser": {RPS: 1, Burst: 2}},
+		WithKeyFunc(func(ctx context.Context) string {
+			caller, _ := CallerFromContext(ctx)
+			return caller
+		}),
+	)
+	rl.now = func() time.Time { return *now }
+
+	return rl
+}
+
+func Test_RateLimiterUsesMethodLimit(t *testing.T) {
+	now := time.Now()
+	rl := newTestRateLimiter(&now)
+
+	for i := 0; i < 2; i++ {
+		if _, ok := rl.allow(followMethod, "alice"); !ok {
+			t.Fatalf("expected call %d to be allowed", i+1)
+		}
+	}
+
+	retryAfter, ok := rl.allow(followMethod, "alice")
+	if ok {
+		t.Fatal("expected call over burst to be rejected")
+	}
+	if retryAfter <= 0 || retryAfter > time.Second {
+		t.Errorf("expected retry after in (0, 1s], got: %s", retryAfter)
+	}
+
+	if _, ok := rl.allow(getUserMethod, "alice"); !ok {
+		t.Error("expected call of another method to use the default limit")
+	}
+
+	now = now.Add(time.Second)
+	if _, ok := rl.allow(followMethod, "alice"); !ok {
+		t.Error("expected call to be allowed after the bucket refill")
+	}
+}
+
+func Test_RateLimiterKeepsBucketPerCaller(t *testing.T) {
+	now := time.Now()
+	rl := newTestRateLimiter(&now)
+
+	rl.allow(followMethod, "alice")
+	rl.allow(followMethod, "alice")
+
+	if _, ok := rl.allow(followMethod, "bob"); !ok {
+		t.Error("expected call of another caller to be allowed")
+	}
+}
+
+func Test_RateLimiterInterceptorReturnsResourceExhausted(t *testing.T) {
+	now := time.Now()
+	rl := newTestRateLimiter(&now)
+	interceptor := rl.UnaryServerInterceptor()
+	info := &grpc.UnaryServerInfo{FullMethod: followMethod}
+	handler := func(ctx context.Context, req any) (any, error) { return req, nil }
+	ctx := ContextWithCaller(context.Background(), "alice")
+
+	var err error
+	for i := 0; i < 3; i++ {
+		_, err = interceptor(ctx, nil, info, handler)
+	}
+
+	if status.Code(err) != codes.ResourceExhausted {
+		t.Errorf("expected code %s, got: %s", codes.ResourceExhausted, status.Code(err))
+	}
+}
@@ -0,0 +1,64 @@
+package grpcserver
+
+import (
+	"context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"log/slog"
+	"love-signal-users/pkg/logger"
+	"love-signal-users/pkg/requestid"
+	"time"
+)
+
+func requestLoggerUnaryInterceptor(log *slog.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		ctx, header, requestLog := withRequestLogger(ctx, log, info.FullMethod)
+		_ = grpc.SetHeader(ctx, header)
+
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		logCallFinished(requestLog, err, start)
+
+		return resp, err
+	}
+}
+
+func requestLoggerStreamInterceptor(log *slog.Logger) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, header, requestLog := withRequestLogger(ss.Context(), log, info.FullMethod)
+		_ = ss.SetHeader(header)
+
+		start := time.Now()
+		err := handler(srv, &contextServerStream{ServerStream: ss, ctx: ctx})
+		logCallFinished(requestLog, err, start)
+
+		return err
+	}
+}
+
+// withRequestLogger takes the request ID from the incoming metadata or generates a new one,
+// and stores it and the request-scoped logger in the context. It returns the header that echoes the request ID.
+func withRequestLogger(ctx context.Context, log *slog.Logger, method string) (context.Context, metadata.MD, *slog.Logger) {
+	var incomingID string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(requestid.Header); len(values) > 0 {
+			incomingID = values[0]
+		}
+	}
+	requestID := requestid.Ensure(incomingID)
+
+	requestLog := logger.RequestLogger(ctx, log, requestID)
+	ctx = requestid.ContextWithRequestID(ctx, requestID)
+	ctx = logger.ContextWithLogger(ctx, requestLog)
+
+	return ctx, metadata.Pairs(requestid.Header, requestID), requestLog.With(slog.String("method", method))
+}
+
+func logCallFinished(log *slog.Logger, err error, start time.Time) {
+	log.Debug(
+		"call finished",
+		slog.String("code", status.Code(err).String()),
+		slog.Duration("duration", time.Since(start)),
+	)
+}
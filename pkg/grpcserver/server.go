@@ -1,27 +1,43 @@
 package grpcserver
 
 import (
+	"errors"
 	"fmt"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/keepalive"
 	"net"
+	"os"
+	"time"
 )
 
 const (
 	defaultPort = "80"
 	network     = "tcp"
+	unixNetwork = "unix"
 )
 
+// ErrDrainTimeout is returned when active calls have not finished in the drain timeout and the server was stopped hard.
+var ErrDrainTimeout = errors.New("drain timeout exceeded, server stopped forcibly")
+
 // Server provides access to the gRPC server.
 type Server struct {
-	App     *grpc.Server
-	notify  chan error
-	address string
+	App        *grpc.Server
+	notify     chan error
+	address    string
+	unixSocket string
+
+	serverOptions         []grpc.ServerOption
+	unaryInterceptors     []grpc.UnaryServerInterceptor
+	streamInterceptors    []grpc.StreamServerInterceptor
+	keepaliveParams       keepalive.ServerParameters
+	connectionIdleTimeout time.Duration
+	drainTimeout          time.Duration
 }
 
-// New returns new gRPC server instance.
+// New returns new gRPC server instance. The caller key of each call is stored in the call context
+// with the caller package, after the interceptors of the options, so that the client identity is known.
 func New(opts ...Option) *Server {
 	s := &Server{
-		App:     grpc.NewServer(),
 		notify:  make(chan error),
 		address: net.JoinHostPort("", defaultPort),
 	}
@@ -31,6 +47,19 @@ func New(opts ...Option) *Server {
 		opt(s)
 	}
 
+	keepaliveParams := s.keepaliveParams
+	if s.connectionIdleTimeout > 0 {
+		keepaliveParams.MaxConnectionIdle = s.connectionIdleTimeout
+	}
+
+	serverOptions := append(
+		s.serverOptions,
+		grpc.KeepaliveParams(keepaliveParams),
+		grpc.ChainUnaryInterceptor(append(s.unaryInterceptors, callerUnaryInterceptor)...),
+		grpc.ChainStreamInterceptor(append(s.streamInterceptors, callerStreamInterceptor)...),
+	)
+	s.App = grpc.NewServer(serverOptions...)
+
 	return s
 }
 
@@ -39,7 +68,7 @@ func (s *Server) Start() {
 	go func() {
 		defer close(s.notify)
 
-		ln, err := net.Listen(network, s.address)
+		ln, err := s.listen()
 		if err != nil {
 			s.notify <- fmt.Errorf("failed to listen: %w", err)
 			return
@@ -49,12 +78,65 @@ func (s *Server) Start() {
 	}()
 }
 
+// Address returns the address the server listens on.
+func (s *Server) Address() string {
+	if s.unixSocket != "" {
+		return unixNetwork + ":" + s.unixSocket
+	}
+
+	return s.address
+}
+
 // Notify - notifies about gRPC server errors.
 func (s *Server) Notify() <-chan error {
 	return s.notify
 }
 
 // Stop - stops the gRPC server.
-func (s *Server) Stop() {
-	s.App.GracefulStop()
+// The server waits for active calls to finish; if the drain timeout is set and exceeded,
+// the server closes all connections and returns ErrDrainTimeout.
+func (s *Server) Stop() error {
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+
+		s.App.GracefulStop()
+	}()
+
+	if s.drainTimeout <= 0 {
+		<-stopped
+		return nil
+	}
+
+	timer := time.NewTimer(s.drainTimeout)
+	defer timer.Stop()
+
+	select {
+	case <-stopped:
+		return nil
+	case <-timer.C:
+		s.App.Stop()
+		<-stopped
+
+		return ErrDrainTimeout
+	}
+}
+
+// ForceStop stops the gRPC server immediately: it closes all connections and cancels active calls.
+// A Stop in progress returns.
+func (s *Server) ForceStop() {
+	s.App.Stop()
+}
+
+func (s *Server) listen() (net.Listener, error) {
+	if s.unixSocket == "" {
+		return net.Listen(network, s.address)
+	}
+
+	// A socket file left by a previous run prevents listening.
+	if err := os.Remove(s.unixSocket); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+
+	return net.Listen(unixNetwork, s.unixSocket)
 }
@@ -0,0 +1,116 @@
+package grpcserver
+
+import (
+	"context"
+	"errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func Test_AddressFromHostAndPortOptions(t *testing.T) {
+	s := New(WithPort("6005"), WithHost("127.0.0.1"))
+
+	if s.Address() != "127.0.0.1:6005" {
+		t.Errorf("expected address `127.0.0.1:6005`, got: `%s`", s.Address())
+	}
+
+	s = New(WithPort("6005"), WithUnixSocket("/tmp/users.sock"))
+
+	if s.Address() != "unix:/tmp/users.sock" {
+		t.Errorf("expected address `unix:/tmp/users.sock`, got: `%s`", s.Address())
+	}
+}
+
+func Test_StopWithoutActiveCallsIsGraceful(t *testing.T) {
+	socket := filepath.Join(t.TempDir(), "users.sock")
+	s := New(WithUnixSocket(socket), WithDrainTimeout(time.Second))
+	start(t, s)
+
+	if err := s.Stop(); err != nil {
+		t.Errorf("expected graceful stop, got: %v", err)
+	}
+}
+
+func Test_StopWithOpenStreamReturnsAfterDrainTimeout(t *testing.T) {
+	const drainTimeout = 100 * time.Millisecond
+
+	socket := filepath.Join(t.TempDir(), "users.sock")
+	s := New(WithUnixSocket(socket), WithDrainTimeout(drainTimeout))
+
+	opened := make(chan struct{})
+	s.App.RegisterService(&grpc.ServiceDesc{
+		ServiceName: "test.Hold",
+		Streams: []grpc.StreamDesc{{
+			StreamName:    "Hold",
+			ServerStreams: true,
+			Handler: func(_ any, stream grpc.ServerStream) error {
+				close(opened)
+				<-stream.Context().Done()
+
+				return stream.Context().Err()
+			},
+		}},
+	}, nil)
+	start(t, s)
+
+	conn, err := grpc.NewClient("unix:"+socket, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	stream, err := conn.NewStream(ctx, &grpc.StreamDesc{ServerStreams: true}, "/test.Hold/Hold")
+	if err != nil {
+		t.Fatalf("failed to open stream: %v", err)
+	}
+	if err = stream.SendMsg(&emptypb.Empty{}); err != nil {
+		t.Fatalf("failed to send request: %v", err)
+	}
+
+	select {
+	case <-opened:
+	case <-time.After(5 * time.Second):
+		t.Fatal("stream was not opened")
+	}
+
+	started := time.Now()
+	if err = s.Stop(); !errors.Is(err, ErrDrainTimeout) {
+		t.Errorf("expected error `%v`, got: %v", ErrDrainTimeout, err)
+	}
+	if elapsed := time.Since(started); elapsed < drainTimeout || elapsed > 5*time.Second {
+		t.Errorf("expected stop after drain timeout %s, took: %s", drainTimeout, elapsed)
+	}
+}
+
+// start starts the server and waits until it accepts connections.
+func start(t *testing.T, s *Server) {
+	t.Helper()
+
+	s.Start()
+	go func() {
+		for range s.Notify() {
+		}
+	}()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		conn, err := net.Dial(unixNetwork, s.unixSocket)
+		if err == nil {
+			_ = conn.Close()
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("server is not listening: %v", err)
+		}
+
+		time.Sleep(10 * time.Millisecond)
+	}
+}
@@ -0,0 +1,75 @@
+package grpcserver
+
+import (
+	"context"
+	"google.golang.org/grpc"
+	"sync"
+	"time"
+)
+
+// Timeouts sets deadlines of unary calls per method.
+// The deadline of the client is kept if it is earlier than the timeout of the method.
+type Timeouts struct {
+	mutex          sync.RWMutex
+	defaultTimeout time.Duration
+	methodTimeouts map[string]time.Duration
+}
+
+// NewTimeouts returns new call timeouts.
+// Method timeouts are keyed by full method name (e.g. "/users.Users/FollowUser") or by bare method name
+// (e.g. "FollowUser"). Methods without own timeout use the default timeout. A non-positive timeout does not limit calls.
+func NewTimeouts(defaultTimeout time.Duration, methodTimeouts map[string]time.Duration) *Timeouts {
+	t := &Timeouts{}
+	t.Set(defaultTimeout, methodTimeouts)
+
+	return t
+}
+
+// Set replaces the timeouts. Calls already running keep their deadlines.
+func (t *Timeouts) Set(defaultTimeout time.Duration, methodTimeouts map[string]time.Duration) {
+	timeouts := make(map[string]time.Duration, len(methodTimeouts))
+	for method, timeout := range methodTimeouts {
+		timeouts[method] = timeout
+	}
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	t.defaultTimeout = defaultTimeout
+	t.methodTimeouts = timeouts
+}
+
+// UnaryServerInterceptor returns a unary server interceptor that sets the deadline of the call.
+func (t *Timeouts) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req any,
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (any, error) {
+		timeout := t.timeoutFor(info.FullMethod)
+		if timeout <= 0 {
+			return handler(ctx, req)
+		}
+
+		ctx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+
+		return handler(ctx, req)
+	}
+}
+
+func (t *Timeouts) timeoutFor(fullMethod string) time.Duration {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+
+	if timeout, ok := t.methodTimeouts[fullMethod]; ok {
+		return timeout
+	}
+
+	if timeout, ok := t.methodTimeouts[methodName(fullMethod)]; ok {
+		return timeout
+	}
+
+	return t.defaultTimeout
+}
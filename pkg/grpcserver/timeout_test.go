@@ -0,0 +1,48 @@
+package grpcserver
+
+import (
+	"context"
+	"google.golang.org/grpc"
+	"testing"
+	"time"
+)
+
+func Test_TimeoutsSetDeadlinePerMethod(t *testing.T) {
+	timeouts := NewTimeouts(time.Minute, map[string]time.Duration{"FollowUser": time.Second})
+	interceptor := timeouts.UnaryServerInterceptor()
+
+	deadlineOf := func(ctx context.Context, method string) time.Duration {
+		var remaining time.Duration
+		handler := func(ctx context.Context, _ any) (any, error) {
+			deadline, ok := ctx.Deadline()
+			if !ok {
+				t.Fatalf("expected deadline of %s", method)
+			}
+			remaining = time.Until(deadline)
+
+			return nil, nil
+		}
+
+		_, _ = interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: method}, handler)
+
+		return remaining
+	}
+
+	if remaining := deadlineOf(context.Background(), followMethod); remaining > time.Second {
+		t.Errorf("expected method timeout of 1s, got: %s", remaining)
+	}
+	if remaining := deadlineOf(context.Background(), getUserMethod); remaining <= time.Second {
+		t.Errorf("expected default timeout of 1m, got: %s", remaining)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if remaining := deadlineOf(ctx, getUserMethod); remaining > 10*time.Millisecond {
+		t.Errorf("expected earlier client deadline to be kept, got: %s", remaining)
+	}
+
+	timeouts.Set(time.Second, nil)
+	if remaining := deadlineOf(context.Background(), followMethod); remaining > time.Second {
+		t.Errorf("expected default timeout of 1s after set, got: %s", remaining)
+	}
+}
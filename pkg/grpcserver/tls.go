@@ -0,0 +1,272 @@
+package grpcserver
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"os"
+	"sync"
+	"time"
+)
+
+const defaultReloadInterval = 10 * time.Second
+
+var (
+	ErrUnknownTLSVersion  = errors.New("unknown TLS version")
+	ErrNoClientCACertsPEM = errors.New("no certificates found in client CA file")
+)
+
+// TLSOption is how options for the TLS configuration are set up.
+type TLSOption func(*tlsReloader)
+
+// WithClientCA sets up a file with CA certificates for verifying client certificates (mutual TLS).
+// Clients without a valid certificate are rejected.
+func WithClientCA(caFile string) TLSOption {
+	return func(r *tlsReloader) {
+		r.caFile = caFile
+	}
+}
+
+// WithMinTLSVersion sets up a minimum TLS version. TLS 1.2 is used by default.
+func WithMinTLSVersion(version uint16) TLSOption {
+	return func(r *tlsReloader) {
+		r.minVersion = version
+	}
+}
+
+// WithReloadInterval sets up how often certificate files are checked for changes.
+func WithReloadInterval(interval time.Duration) TLSOption {
+	return func(r *tlsReloader) {
+		r.reloadInterval = interval
+	}
+}
+
+// NewTLSConfig returns a TLS configuration for the gRPC server.
+// Certificate files are reloaded from disk when they change, so certificates can be rotated without a restart.
+func NewTLSConfig(certFile, keyFile string, opts ...TLSOption) (*tls.Config, error) {
+	const op = "grpcserver.NewTLSConfig"
+
+	r := &tlsReloader{
+		certFile:       certFile,
+		keyFile:        keyFile,
+		minVersion:     tls.VersionTLS12,
+		reloadInterval: defaultReloadInterval,
+	}
+
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	if err := r.load(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &tls.Config{
+		MinVersion:         r.minVersion,
+		GetConfigForClient: r.configForClient,
+	}, nil
+}
+
+// ParseTLSVersion parses a TLS version in the "1.2" form.
+func ParseTLSVersion(version string) (uint16, error) {
+	switch version {
+	case "1.0":
+		return tls.VersionTLS10, nil
+	case "1.1":
+		return tls.VersionTLS11, nil
+	case "1.2":
+		return tls.VersionTLS12, nil
+	case "1.3":
+		return tls.VersionTLS13, nil
+	default:
+		return 0, fmt.Errorf("%w: %s", ErrUnknownTLSVersion, version)
+	}
+}
+
+// ClientCertificateFromContext returns the verified certificate of the client of the request.
+func ClientCertificateFromContext(ctx context.Context) (*x509.Certificate, bool) {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return nil, false
+	}
+
+	tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(tlsInfo.State.VerifiedChains) == 0 || len(tlsInfo.State.VerifiedChains[0]) == 0 {
+		return nil, false
+	}
+
+	return tlsInfo.State.VerifiedChains[0][0], true
+}
+
+// certificateIdentity returns the identity of the certificate owner:
+// the common name, or the first URI or DNS subject alternative name.
+func certificateIdentity(cert *x509.Certificate) string {
+	switch {
+	case cert.Subject.CommonName != "":
+		return cert.Subject.CommonName
+	case len(cert.URIs) > 0:
+		return cert.URIs[0].String()
+	case len(cert.DNSNames) > 0:
+		return cert.DNSNames[0]
+	default:
+		return ""
+	}
+}
+
+// contextWithClientIdentity stores the identity of the client certificate in the context as the caller identity.
+func contextWithClientIdentity(ctx context.Context) context.Context {
+	cert, ok := ClientCertificateFromContext(ctx)
+	if !ok {
+		return ctx
+	}
+
+	identity := certificateIdentity(cert)
+	if identity == "" {
+		return ctx
+	}
+
+	return ContextWithCaller(ctx, identity)
+}
+
+func clientIdentityUnaryInterceptor(
+	ctx context.Context,
+	req any,
+	_ *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (any, error) {
+	return handler(contextWithClientIdentity(ctx), req)
+}
+
+func clientIdentityStreamInterceptor(
+	srv any,
+	ss grpc.ServerStream,
+	_ *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) error {
+	return handler(srv, &contextServerStream{
+		ServerStream: ss,
+		ctx:          contextWithClientIdentity(ss.Context()),
+	})
+}
+
+// contextServerStream is a server stream with a replaced context.
+type contextServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *contextServerStream) Context() context.Context {
+	return s.ctx
+}
+
+// tlsReloader keeps the server certificate and the client CA pool up to date with the files on disk.
+type tlsReloader struct {
+	certFile       string
+	keyFile        string
+	caFile         string
+	minVersion     uint16
+	reloadInterval time.Duration
+
+	mutex       sync.RWMutex
+	cert        *tls.Certificate
+	clientCAs   *x509.CertPool
+	modTimes    map[string]time.Time
+	lastChecked time.Time
+}
+
+func (r *tlsReloader) configForClient(*tls.ClientHelloInfo) (*tls.Config, error) {
+	r.reloadIfChanged()
+
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	cfg := &tls.Config{
+		MinVersion:   r.minVersion,
+		Certificates: []tls.Certificate{*r.cert},
+	}
+
+	if r.clientCAs != nil {
+		cfg.ClientCAs = r.clientCAs
+		cfg.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+
+	return cfg, nil
+}
+
+// reloadIfChanged reloads the files if any of them has changed since the last load.
+// If the new files are invalid, the previously loaded certificates continue to be used.
+func (r *tlsReloader) reloadIfChanged() {
+	r.mutex.Lock()
+	if time.Since(r.lastChecked) < r.reloadInterval {
+		r.mutex.Unlock()
+		return
+	}
+	r.lastChecked = time.Now()
+	modTimes := r.modTimes
+	r.mutex.Unlock()
+
+	for file, modTime := range modTimes {
+		info, err := os.Stat(file)
+		if err != nil {
+			return
+		}
+
+		if !info.ModTime().Equal(modTime) {
+			_ = r.load()
+			return
+		}
+	}
+}
+
+func (r *tlsReloader) load() error {
+	modTimes := make(map[string]time.Time)
+	for _, file := range r.files() {
+		info, err := os.Stat(file)
+		if err != nil {
+			return err
+		}
+		modTimes[file] = info.ModTime()
+	}
+
+	cert, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		return err
+	}
+
+	var clientCAs *x509.CertPool
+	if r.caFile != "" {
+		caPEM, err := os.ReadFile(r.caFile)
+		if err != nil {
+			return err
+		}
+
+		clientCAs = x509.NewCertPool()
+		if !clientCAs.AppendCertsFromPEM(caPEM) {
+			return ErrNoClientCACertsPEM
+		}
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.cert = &cert
+	r.clientCAs = clientCAs
+	r.modTimes = modTimes
+	r.lastChecked = time.Now()
+
+	return nil
+}
+
+func (r *tlsReloader) files() []string {
+	files := []string{r.certFile, r.keyFile}
+	if r.caFile != "" {
+		files = append(files, r.caFile)
+	}
+
+	return files
+}
@@ -0,0 +1,108 @@
+package grpcserver
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func writeSelfSignedCert(t *testing.T, dir, commonName string) (string, string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	certFile := filepath.Join(dir, "server.crt")
+	keyFile := filepath.Join(dir, "server.key")
+	if err = os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err = os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	return certFile, keyFile
+}
+
+func Test_TLSConfigReloadsChangedCertificate(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile := writeSelfSignedCert(t, dir, "first")
+
+	cfg, err := NewTLSConfig(certFile, keyFile, WithReloadInterval(0), WithMinTLSVersion(tls.VersionTLS13))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	first, err := cfg.GetConfigForClient(&tls.ClientHelloInfo{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if first.MinVersion != tls.VersionTLS13 {
+		t.Errorf("expected min version %d, got: %d", tls.VersionTLS13, first.MinVersion)
+	}
+	if first.ClientAuth != tls.NoClientCert {
+		t.Errorf("expected no client auth without client CA, got: %s", first.ClientAuth)
+	}
+
+	writeSelfSignedCert(t, dir, "second")
+	later := time.Now().Add(time.Minute)
+	for _, file := range []string{certFile, keyFile} {
+		if err = os.Chtimes(file, later, later); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	second, err := cfg.GetConfigForClient(&tls.ClientHelloInfo{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if bytes.Equal(first.Certificates[0].Certificate[0], second.Certificates[0].Certificate[0]) {
+		t.Error("expected certificate to be reloaded after the files changed")
+	}
+}
+
+func Test_TLSConfigRequiresClientCertWithClientCA(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile := writeSelfSignedCert(t, dir, "server")
+
+	cfg, err := NewTLSConfig(certFile, keyFile, WithClientCA(certFile))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	clientCfg, err := cfg.GetConfigForClient(&tls.ClientHelloInfo{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if clientCfg.ClientAuth != tls.RequireAndVerifyClientCert {
+		t.Errorf("expected client auth %s, got: %s", tls.RequireAndVerifyClientCert, clientCfg.ClientAuth)
+	}
+}
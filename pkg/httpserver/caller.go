@@ -0,0 +1,19 @@
+package httpserver
+
+import (
+	"love-signal-users/pkg/caller"
+	"net"
+	"net/http"
+)
+
+// callerMiddleware stores the caller of the request in its context: the client address without a port.
+func callerMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		address := r.RemoteAddr
+		if host, _, err := net.SplitHostPort(address); err == nil {
+			address = host
+		}
+
+		next.ServeHTTP(w, r.WithContext(caller.ContextWithCaller(r.Context(), address)))
+	})
+}
@@ -0,0 +1,56 @@
+package httpserver
+
+import (
+	"log/slog"
+	"net"
+	"time"
+)
+
+// Option is how options for the Server are set up.
+type Option func(*Server)
+
+// WithPort sets up a port for HTTP server.
+func WithPort(port string) Option {
+	return func(s *Server) {
+		host, _, _ := net.SplitHostPort(s.App.Addr)
+		s.App.Addr = net.JoinHostPort(host, port)
+	}
+}
+
+// WithHost sets up a host to bind the HTTP server to. The server binds to all interfaces by default.
+func WithHost(host string) Option {
+	return func(s *Server) {
+		_, port, _ := net.SplitHostPort(s.App.Addr)
+		s.App.Addr = net.JoinHostPort(host, port)
+	}
+}
+
+// WithReadTimeout sets up a timeout for reading the request.
+func WithReadTimeout(timeout time.Duration) Option {
+	return func(s *Server) {
+		s.App.ReadTimeout = timeout
+	}
+}
+
+// WithWriteTimeout sets up a timeout for writing the response.
+func WithWriteTimeout(timeout time.Duration) Option {
+	return func(s *Server) {
+		s.App.WriteTimeout = timeout
+	}
+}
+
+// WithShutdownTimeout sets up how long the server waits for active requests on stop.
+func WithShutdownTimeout(timeout time.Duration) Option {
+	return func(s *Server) {
+		s.shutdownTimeout = timeout
+	}
+}
+
+// WithRequestLogger sets up request IDs for all requests of the HTTP server.
+// The request ID is taken from the request header or generated, echoed back in the response header,
+// and the request-scoped logger with it is stored in the request context.
+func WithRequestLogger(log *slog.Logger) Option {
+	return func(s *Server) {
+		s.App.Handler = requestLoggerMiddleware(log, s.App.Handler)
+	}
+}
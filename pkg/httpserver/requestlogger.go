@@ -0,0 +1,45 @@
+package httpserver
+
+import (
+	"log/slog"
+	"love-signal-users/pkg/logger"
+	"love-signal-users/pkg/requestid"
+	"net/http"
+	"time"
+)
+
+// statusRecorder records the status code of the response.
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func requestLoggerMiddleware(log *slog.Logger, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		requestID := requestid.Ensure(r.Header.Get(requestid.Header))
+		w.Header().Set(requestid.Header, requestID)
+
+		requestLog := logger.RequestLogger(ctx, log, requestID)
+		ctx = requestid.ContextWithRequestID(ctx, requestID)
+		ctx = logger.ContextWithLogger(ctx, requestLog)
+
+		recorder := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		start := time.Now()
+		next.ServeHTTP(recorder, r.WithContext(ctx))
+
+		requestLog.Debug(
+			"request finished",
+			slog.String("method", r.Method),
+			slog.String("path", r.URL.Path),
+			slog.Int("status", recorder.status),
+			slog.Duration("duration", time.Since(start)),
+		)
+	})
+}
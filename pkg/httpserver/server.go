@@ -0,0 +1,77 @@
+package httpserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+)
+
+const (
+	defaultPort            = "80"
+	defaultReadTimeout     = 5 * time.Second
+	defaultWriteTimeout    = 5 * time.Second
+	defaultShutdownTimeout = 5 * time.Second
+)
+
+// Server provides access to the HTTP server.
+type Server struct {
+	App             *http.Server
+	notify          chan error
+	shutdownTimeout time.Duration
+}
+
+// New returns new HTTP server instance. The caller of each request, its client address,
+// is stored in the request context.
+func New(handler http.Handler, opts ...Option) *Server {
+	s := &Server{
+		App: &http.Server{
+			Addr:         net.JoinHostPort("", defaultPort),
+			Handler:      callerMiddleware(handler),
+			ReadTimeout:  defaultReadTimeout,
+			WriteTimeout: defaultWriteTimeout,
+		},
+		notify:          make(chan error, 1),
+		shutdownTimeout: defaultShutdownTimeout,
+	}
+
+	// Custom options
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
+}
+
+// Start - starts the HTTP server.
+func (s *Server) Start() {
+	go func() {
+		defer close(s.notify)
+
+		err := s.App.ListenAndServe()
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
+			s.notify <- fmt.Errorf("failed to serve: %w", err)
+		}
+	}()
+}
+
+// Notify - notifies about HTTP server errors.
+func (s *Server) Notify() <-chan error {
+	return s.notify
+}
+
+// Stop - gracefully stops the HTTP server, waiting for active requests no longer than the shutdown timeout.
+func (s *Server) Stop() error {
+	ctx, cancel := context.WithTimeout(context.Background(), s.shutdownTimeout)
+	defer cancel()
+
+	return s.App.Shutdown(ctx)
+}
+
+// ForceStop stops the HTTP server immediately: it closes the listeners and all connections.
+// A Stop in progress returns.
+func (s *Server) ForceStop() error {
+	return s.App.Close()
+}
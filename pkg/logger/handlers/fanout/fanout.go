@@ -0,0 +1,68 @@
+package fanout
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+)
+
+// Handler is a slog handler that writes records to all of its handlers.
+// Each handler filters records by its own level.
+type Handler struct {
+	handlers []slog.Handler
+}
+
+// New returns new fan-out handler.
+func New(handlers ...slog.Handler) *Handler {
+	return &Handler{
+		handlers: handlers,
+	}
+}
+
+// Enabled reports whether any of the handlers handles records at the level.
+func (h *Handler) Enabled(ctx context.Context, level slog.Level) bool {
+	for _, handler := range h.handlers {
+		if handler.Enabled(ctx, level) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Handle writes the record to the handlers enabled at its level.
+// A failed handler does not prevent writing to the others.
+func (h *Handler) Handle(ctx context.Context, rec slog.Record) error {
+	var errs []error
+	for _, handler := range h.handlers {
+		if !handler.Enabled(ctx, rec.Level) {
+			continue
+		}
+
+		if err := handler.Handle(ctx, rec.Clone()); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// WithAttrs returns new fan-out handler whose handlers have the attributes.
+func (h *Handler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	handlers := make([]slog.Handler, 0, len(h.handlers))
+	for _, handler := range h.handlers {
+		handlers = append(handlers, handler.WithAttrs(attrs))
+	}
+
+	return New(handlers...)
+}
+
+// WithGroup returns new fan-out handler whose handlers have the group.
+func (h *Handler) WithGroup(name string) slog.Handler {
+	handlers := make([]slog.Handler, 0, len(h.handlers))
+	for _, handler := range h.handlers {
+		handlers = append(handlers, handler.WithGroup(name))
+	}
+
+	return New(handlers...)
+}
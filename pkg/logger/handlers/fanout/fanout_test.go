@@ -0,0 +1,31 @@
+package fanout
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func Test_HandlerWritesToSinksByTheirLevels(t *testing.T) {
+	debugBuf := &bytes.Buffer{}
+	errorBuf := &bytes.Buffer{}
+
+	log := slog.New(New(
+		slog.NewJSONHandler(debugBuf, &slog.HandlerOptions{Level: slog.LevelDebug}),
+		slog.NewJSONHandler(errorBuf, &slog.HandlerOptions{Level: slog.LevelError}),
+	)).With(slog.String("op", "test"))
+
+	log.Debug("debug message")
+	log.Error("error message")
+
+	if !strings.Contains(debugBuf.String(), "debug message") || !strings.Contains(debugBuf.String(), "error message") {
+		t.Errorf("expected debug sink to have both messages, got: %s", debugBuf.String())
+	}
+	if strings.Contains(errorBuf.String(), "debug message") {
+		t.Errorf("expected error sink not to have debug message, got: %s", errorBuf.String())
+	}
+	if !strings.Contains(errorBuf.String(), `"op":"test"`) {
+		t.Errorf("expected error sink to have attributes, got: %s", errorBuf.String())
+	}
+}
@@ -0,0 +1,250 @@
+package sampling
+
+import (
+	"context"
+	"golang.org/x/time/rate"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+const (
+	// RepeatedKey is the key of the number of suppressed records in the summary line.
+	RepeatedKey = "repeated"
+
+	opKey         = "op"
+	defaultWindow = 10 * time.Second
+)
+
+// Limit is the limit of records with the same message and operation.
+type Limit struct {
+	// Burst is how many records pass at once. It must be at least 1, otherwise all records are suppressed.
+	Burst int
+	// Rate is how many records per second pass after the burst.
+	Rate float64
+}
+
+// Handler is a slog handler that samples repeated records.
+// Records with the same level, message and "op" attribute over the limit of their level are suppressed,
+// and one summary line with the number of suppressed records is written at the end of the window.
+// Windows are driven by the records: the summaries of a window are written before the first sampled record
+// after its end, or on Close. The handler runs no goroutines, so an unclosed handler leaks nothing.
+// Records of levels without limits are not sampled.
+type Handler struct {
+	next  slog.Handler
+	state *state
+	op    string
+}
+
+// state is shared by the handler and the handlers derived from it with WithAttrs and WithGroup.
+type state struct {
+	mutex   sync.Mutex
+	window  time.Duration
+	limits  map[slog.Level]Limit
+	entries map[key]*entry
+	now     func() time.Time
+
+	// windowEnd is the time when the summaries of the current window are due.
+	windowEnd time.Time
+}
+
+type key struct {
+	level   slog.Level
+	message string
+	op      string
+}
+
+type entry struct {
+	limiter    *rate.Limiter
+	suppressed int
+	lastSeen   time.Time
+
+	// The handler and record of the last suppressed record, to write the summary with its attributes.
+	handler slog.Handler
+	record  slog.Record
+}
+
+// Option is how options for the Handler are set up.
+type Option func(s *state)
+
+// WithWindow sets up the window after which summaries of suppressed records are written, 10 seconds by default.
+func WithWindow(window time.Duration) Option {
+	return func(s *state) {
+		s.window = window
+	}
+}
+
+// WithLimit sets up the limit of records of the level.
+func WithLimit(level slog.Level, limit Limit) Option {
+	return func(s *state) {
+		s.limits[level] = limit
+	}
+}
+
+// New returns new sampling handler. Close writes the summaries of the current window.
+func New(next slog.Handler, opts ...Option) *Handler {
+	s := &state{
+		window:  defaultWindow,
+		limits:  make(map[slog.Level]Limit),
+		entries: make(map[key]*entry),
+		now:     time.Now,
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return &Handler{
+		next:  next,
+		state: s,
+	}
+}
+
+// Enabled reports whether the next handler handles records at the level.
+func (h *Handler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+// Handle writes the record to the next handler unless it is over the limit.
+func (h *Handler) Handle(ctx context.Context, rec slog.Record) error {
+	limit, ok := h.state.limits[rec.Level]
+	if !ok {
+		return h.next.Handle(ctx, rec)
+	}
+
+	op := h.op
+	rec.Attrs(func(a slog.Attr) bool {
+		if a.Key == opKey {
+			op = a.Value.String()
+			return false
+		}
+
+		return true
+	})
+
+	if !h.state.allow(key{level: rec.Level, message: rec.Message, op: op}, limit, h.next, rec) {
+		return nil
+	}
+
+	return h.next.Handle(ctx, rec)
+}
+
+// WithAttrs returns new sampling handler whose next handler has the attributes.
+func (h *Handler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	op := h.op
+	for _, a := range attrs {
+		if a.Key == opKey {
+			op = a.Value.String()
+		}
+	}
+
+	return &Handler{
+		next:  h.next.WithAttrs(attrs),
+		state: h.state,
+		op:    op,
+	}
+}
+
+// WithGroup returns new sampling handler whose next handler has the group.
+func (h *Handler) WithGroup(name string) slog.Handler {
+	return &Handler{
+		next:  h.next.WithGroup(name),
+		state: h.state,
+		op:    h.op,
+	}
+}
+
+// Close writes summaries of the records suppressed so far.
+func (h *Handler) Close() error {
+	h.state.flush()
+
+	return nil
+}
+
+// allow reports whether the record is in the limit. Suppressed records are counted.
+// If the window has ended, the summaries of its suppressed records are written first.
+func (s *state) allow(k key, limit Limit, handler slog.Handler, rec slog.Record) bool {
+	s.mutex.Lock()
+
+	now := s.now()
+	if s.windowEnd.IsZero() {
+		s.windowEnd = now.Add(s.window)
+	}
+
+	var summaries []summary
+	if !now.Before(s.windowEnd) {
+		summaries = s.endWindow(now)
+	}
+
+	e, ok := s.entries[k]
+	if !ok {
+		e = &entry{limiter: rate.NewLimiter(rate.Limit(limit.Rate), limit.Burst)}
+		s.entries[k] = e
+	}
+	e.lastSeen = now
+
+	allowed := e.limiter.AllowN(now, 1)
+	if !allowed {
+		e.suppressed++
+		e.handler = handler
+		e.record = rec.Clone()
+	}
+	s.mutex.Unlock()
+
+	writeSummaries(summaries)
+
+	return allowed
+}
+
+// flush writes summaries of the suppressed records and starts the next window.
+func (s *state) flush() {
+	s.mutex.Lock()
+	summaries := s.endWindow(s.now())
+	s.mutex.Unlock()
+
+	writeSummaries(summaries)
+}
+
+// summary is the summary line of suppressed records and the handler to write it with.
+type summary struct {
+	handler slog.Handler
+	record  slog.Record
+}
+
+// endWindow returns summaries of the suppressed records, forgets records not seen in the window
+// and starts the next window. The mutex must be held.
+func (s *state) endWindow(now time.Time) []summary {
+	s.windowEnd = now.Add(s.window)
+
+	summaries := make([]summary, 0)
+	for k, e := range s.entries {
+		if e.suppressed > 0 {
+			rec := slog.NewRecord(now, e.record.Level, e.record.Message, e.record.PC)
+			e.record.Attrs(func(a slog.Attr) bool {
+				rec.AddAttrs(a)
+				return true
+			})
+			rec.AddAttrs(slog.Int(RepeatedKey, e.suppressed))
+
+			summaries = append(summaries, summary{handler: e.handler, record: rec})
+			e.suppressed = 0
+			e.handler = nil
+			e.record = slog.Record{}
+
+			continue
+		}
+
+		if now.Sub(e.lastSeen) >= s.window {
+			delete(s.entries, k)
+		}
+	}
+
+	return summaries
+}
+
+// writeSummaries writes the summaries. They are written without the mutex held, as the next handler may be slow.
+func writeSummaries(summaries []summary) {
+	for _, sum := range summaries {
+		_ = sum.handler.Handle(context.Background(), sum.record)
+	}
+}
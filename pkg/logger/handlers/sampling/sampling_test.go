@@ -0,0 +1,79 @@
+package sampling
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test_HandlerCollapsesRepeatedRecords(t *testing.T) {
+	buf := &bytes.Buffer{}
+	handler := New(
+		slog.NewJSONHandler(buf, nil),
+		WithWindow(time.Hour),
+		WithLimit(slog.LevelWarn, Limit{Burst: 2}),
+	)
+	log := slog.New(handler)
+
+	for range 5 {
+		log.With(slog.String("op", "repository.users.User")).Warn("user not found")
+	}
+	log.With(slog.String("op", "usecase.user.Execute")).Warn("user not found")
+	log.Error("user not found")
+
+	if err := handler.Close(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 5 {
+		t.Fatalf("expected 2 sampled, 2 not limited and 1 summary lines, got %d: %s", len(lines), buf.String())
+	}
+
+	var summary map[string]any
+	if err := json.Unmarshal([]byte(lines[len(lines)-1]), &summary); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if summary[RepeatedKey] != float64(3) || summary["op"] != "repository.users.User" {
+		t.Errorf("expected summary of 3 suppressed records, got: %v", summary)
+	}
+}
+
+func Test_SummaryWrittenByFirstRecordAfterWindow(t *testing.T) {
+	buf := &bytes.Buffer{}
+	handler := New(
+		slog.NewJSONHandler(buf, nil),
+		WithWindow(time.Minute),
+		WithLimit(slog.LevelWarn, Limit{Burst: 1, Rate: 1}),
+	)
+
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	handler.state.now = func() time.Time { return now }
+	log := slog.New(handler)
+
+	for range 3 {
+		log.Warn("user not found")
+	}
+	if lines := strings.Split(strings.TrimSpace(buf.String()), "\n"); len(lines) != 1 {
+		t.Fatalf("expected 1 line before the end of the window, got %d: %s", len(lines), buf.String())
+	}
+
+	now = now.Add(time.Minute)
+	log.Warn("user not found")
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("expected sampled, summary and new window lines, got %d: %s", len(lines), buf.String())
+	}
+
+	var summary map[string]any
+	if err := json.Unmarshal([]byte(lines[1]), &summary); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if summary[RepeatedKey] != float64(2) {
+		t.Errorf("expected summary of 2 suppressed records before the new record, got: %v", summary)
+	}
+}
@@ -1,6 +1,10 @@
 package slogpretty
 
-import "love-signal-users/pkg/logger/color"
+import (
+	"io"
+	"love-signal-users/pkg/logger/color"
+	"os"
+)
 
 type PrettyHandlerOption func(h *PrettyHandler)
 
@@ -15,3 +19,59 @@ func WithOutputEmptyAttrs() PrettyHandlerOption {
 		h.outputEmptyAttrs = true
 	}
 }
+
+// WithAutoColor enables colors if the output is a terminal and the NO_COLOR environment variable is not set.
+func WithAutoColor() PrettyHandlerOption {
+	return func(h *PrettyHandler) {
+		if isTerminal(h.writer) && os.Getenv("NO_COLOR") == "" {
+			h.colorize = color.WithColorize
+		} else {
+			h.colorize = color.WithoutColorize
+		}
+	}
+}
+
+// WithTimeFormat sets up the layout of the time of the line, "[15:04:05.000]" by default.
+func WithTimeFormat(layout string) PrettyHandlerOption {
+	return func(h *PrettyHandler) {
+		h.timeFormat = layout
+	}
+}
+
+// WithKeyValue renders attributes as key=value pairs on the line instead of JSON.
+// Messages are padded to the width, so that the attributes of the lines are aligned.
+func WithKeyValue(messageWidth int) PrettyHandlerOption {
+	return func(h *PrettyHandler) {
+		h.keyValue = true
+		h.messageWidth = messageWidth
+	}
+}
+
+// WithDottedGroups renders attributes of groups as keys joined with dots, e.g. group.key.
+func WithDottedGroups() PrettyHandlerOption {
+	return func(h *PrettyHandler) {
+		h.dottedGroups = true
+	}
+}
+
+// WithMultilineErrors renders the error chain of the sl.Err attribute after the line, each error of the errors.Unwrap chain
+// on its own line.
+func WithMultilineErrors() PrettyHandlerOption {
+	return func(h *PrettyHandler) {
+		h.multilineErrors = true
+	}
+}
+
+func isTerminal(w io.Writer) bool {
+	file, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		return false
+	}
+
+	return info.Mode()&os.ModeCharDevice != 0
+}
@@ -4,17 +4,26 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log/slog"
 	"love-signal-users/pkg/logger/color"
+	"path/filepath"
+	"sort"
+	"strconv"
 	"strings"
 	"sync"
+	"unicode/utf8"
 )
 
 const (
-	timeFormat = "[15:04:05.000]"
-	lineBreak  = "\n"
+	timeFormat          = "[15:04:05.000]"
+	lineBreak           = "\n"
+	defaultMessageWidth = 40
+
+	errorKey          = "error"
+	errorChainDivider = ": "
 )
 
 type PrettyHandler struct {
@@ -27,6 +36,11 @@ type PrettyHandler struct {
 
 	colorize         color.Colorizer
 	outputEmptyAttrs bool
+	timeFormat       string
+	keyValue         bool
+	messageWidth     int
+	dottedGroups     bool
+	multilineErrors  bool
 }
 
 func NewPrettyHandler(
@@ -53,6 +67,8 @@ func NewPrettyHandler(
 
 		colorize:         color.WithoutColorize,
 		outputEmptyAttrs: false,
+		timeFormat:       timeFormat,
+		messageWidth:     defaultMessageWidth,
 	}
 
 	for _, setter := range setters {
@@ -66,12 +82,30 @@ func (h *PrettyHandler) Handle(ctx context.Context, rec slog.Record) error {
 	level := h.formatLevel(rec)
 	time := h.formatTime(rec)
 	message := h.formatMessage(rec)
-	attributes, err := h.formatAttributes(ctx, rec)
+	attrs, err := h.computeAttrs(ctx, rec)
+	if err != nil {
+		return err
+	}
+
+	source := h.formatSource(attrs)
+	errorLines := h.formatErrorLines(attrs, recordError(rec))
+	if h.dottedGroups {
+		attrs = flatten("", attrs, make(map[string]any, len(attrs)))
+	}
+
+	attributes, err := h.formatAttributes(attrs)
 	if err != nil {
 		return err
 	}
 
-	logLine := h.GenerateLogLine(level, time, message, attributes)
+	if len(source) > 0 {
+		message = source + " " + message
+	}
+	if h.keyValue && len(attributes) > 0 {
+		message = h.alignMessage(message, rec.Message)
+	}
+
+	logLine := h.GenerateLogLine(level, time, message, attributes) + errorLines
 
 	_, err = io.WriteString(h.writer, logLine)
 	if err != nil {
@@ -92,6 +126,11 @@ func (h *PrettyHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
 
 		colorize:         h.colorize,
 		outputEmptyAttrs: h.outputEmptyAttrs,
+		timeFormat:       h.timeFormat,
+		keyValue:         h.keyValue,
+		messageWidth:     h.messageWidth,
+		dottedGroups:     h.dottedGroups,
+		multilineErrors:  h.multilineErrors,
 	}
 }
 
@@ -106,6 +145,11 @@ func (h *PrettyHandler) WithGroup(name string) slog.Handler {
 
 		colorize:         h.colorize,
 		outputEmptyAttrs: h.outputEmptyAttrs,
+		timeFormat:       h.timeFormat,
+		keyValue:         h.keyValue,
+		messageWidth:     h.messageWidth,
+		dottedGroups:     h.dottedGroups,
+		multilineErrors:  h.multilineErrors,
 	}
 }
 
@@ -181,7 +225,7 @@ func (h *PrettyHandler) formatTime(rec slog.Record) string {
 	var time string
 	timeAttr := slog.Attr{
 		Key:   slog.TimeKey,
-		Value: slog.StringValue(rec.Time.Format(timeFormat)),
+		Value: slog.StringValue(rec.Time.Format(h.timeFormat)),
 	}
 	if h.replaceAttr != nil {
 		timeAttr = h.replaceAttr([]string{}, timeAttr)
@@ -209,13 +253,13 @@ func (h *PrettyHandler) formatMessage(rec slog.Record) string {
 	return message
 }
 
-func (h *PrettyHandler) formatAttributes(ctx context.Context, rec slog.Record) (string, error) {
-	attrs, err := h.computeAttrs(ctx, rec)
-	if err != nil {
-		return "", err
+func (h *PrettyHandler) formatAttributes(attrs map[string]any) (string, error) {
+	if h.keyValue {
+		return h.formatKeyValues(attrs)
 	}
 
 	var attrsAsBytes []byte
+	var err error
 	if h.outputEmptyAttrs || len(attrs) > 0 {
 		attrsAsBytes, err = json.MarshalIndent(attrs, "", "  ")
 		if err != nil {
@@ -226,6 +270,184 @@ func (h *PrettyHandler) formatAttributes(ctx context.Context, rec slog.Record) (
 	return string(attrsAsBytes), nil
 }
 
+// formatKeyValues renders the attributes as key=value pairs sorted by key.
+// Values of groups that are not flattened are rendered as JSON.
+func (h *PrettyHandler) formatKeyValues(attrs map[string]any) (string, error) {
+	keys := make([]string, 0, len(attrs))
+	for key := range attrs {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, 0, len(keys))
+	for _, key := range keys {
+		value, err := formatValue(attrs[key])
+		if err != nil {
+			return "", err
+		}
+
+		pairs = append(pairs, quoteIfNeeded(key)+"="+value)
+	}
+
+	return strings.Join(pairs, " "), nil
+}
+
+// alignMessage pads the message, so that the attributes of the lines start at the same column.
+func (h *PrettyHandler) alignMessage(message, plainMessage string) string {
+	padding := h.messageWidth - utf8.RuneCountInString(plainMessage)
+	if padding <= 0 {
+		return message
+	}
+
+	return message + strings.Repeat(" ", padding)
+}
+
+// formatSource removes the source added by AddSource from the attributes and returns it as file:line.
+func (h *PrettyHandler) formatSource(attrs map[string]any) string {
+	source, ok := attrs[slog.SourceKey].(map[string]any)
+	if !ok {
+		return ""
+	}
+	delete(attrs, slog.SourceKey)
+
+	file, _ := source["file"].(string)
+	line, _ := source["line"].(float64)
+	if file == "" {
+		return ""
+	}
+
+	// The file is shown with its directory, as there are many files with the same name in the project.
+	file = filepath.Join(filepath.Base(filepath.Dir(file)), filepath.Base(file))
+
+	return h.colorize(color.DarkGray, file+":"+strconv.Itoa(int(line)))
+}
+
+// formatErrorLines removes the error from the attributes, if errors are rendered on multiple lines,
+// and returns the error chain with each wrapping error on its own line.
+// The chain is taken from the error of the record; if the attribute holds no error, or was replaced,
+// e.g. masked by the redaction policy, the message of the attribute is rendered on one line.
+func (h *PrettyHandler) formatErrorLines(attrs map[string]any, err error) string {
+	if !h.multilineErrors {
+		return ""
+	}
+
+	errorMessage, ok := attrs[errorKey].(string)
+	if !ok {
+		return ""
+	}
+	delete(attrs, errorKey)
+
+	parts := []string{errorMessage}
+	if err != nil && err.Error() == errorMessage {
+		parts = errorChain(err)
+	}
+
+	prefix := "  " + errorKey + errorChainDivider
+	indent := strings.Repeat(" ", len(prefix))
+
+	out := strings.Builder{}
+	for i, part := range parts {
+		if i == 0 {
+			out.WriteString(prefix)
+		} else {
+			out.WriteString(indent)
+		}
+		out.WriteString(h.colorize(color.LightRed, part))
+		out.WriteString(lineBreak)
+	}
+
+	return out.String()
+}
+
+// recordError returns the error of the error attribute of the record, or nil if it holds no error.
+func recordError(rec slog.Record) error {
+	var err error
+	rec.Attrs(func(a slog.Attr) bool {
+		if a.Key != errorKey {
+			return true
+		}
+
+		err, _ = a.Value.Any().(error)
+
+		return false
+	})
+
+	return err
+}
+
+// errorChain returns the messages the errors of the errors.Unwrap chain add to the errors they wrap.
+// An error whose message does not end with the message of the wrapped error, e.g. one that wraps
+// several errors, is returned with its whole message.
+func errorChain(err error) []string {
+	parts := make([]string, 0)
+	for err != nil {
+		message := err.Error()
+
+		wrapped := errors.Unwrap(err)
+		if wrapped == nil {
+			return append(parts, message)
+		}
+
+		own, ok := strings.CutSuffix(message, wrapped.Error())
+		if !ok {
+			return append(parts, message)
+		}
+		if own = strings.TrimSuffix(own, errorChainDivider); own != "" {
+			parts = append(parts, own)
+		}
+
+		err = wrapped
+	}
+
+	return parts
+}
+
+// flatten puts the values of nested groups to the result with dotted keys.
+func flatten(prefix string, attrs map[string]any, result map[string]any) map[string]any {
+	for key, value := range attrs {
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+
+		if group, ok := value.(map[string]any); ok {
+			flatten(key, group, result)
+			continue
+		}
+
+		result[key] = value
+	}
+
+	return result
+}
+
+func formatValue(value any) (string, error) {
+	switch v := value.(type) {
+	case string:
+		return quoteIfNeeded(v), nil
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case nil:
+		return "null", nil
+	default:
+		valueAsBytes, err := json.Marshal(v)
+		if err != nil {
+			return "", fmt.Errorf("error when marshaling attr value: %w", err)
+		}
+
+		return string(valueAsBytes), nil
+	}
+}
+
+func quoteIfNeeded(value string) string {
+	if value == "" || strings.ContainsAny(value, " =\"\t\n") {
+		return strconv.Quote(value)
+	}
+
+	return value
+}
+
 func (h *PrettyHandler) GenerateLogLine(level, time, message, attributes string) string {
 	out := strings.Builder{}
 	if len(time) > 0 {
@@ -243,7 +465,6 @@ func (h *PrettyHandler) GenerateLogLine(level, time, message, attributes string)
 	if len(attributes) > 0 {
 		out.WriteString(h.colorize(color.DarkGray, attributes))
 	}
-	out.WriteString(lineBreak)
 
-	return strings.Trim(out.String(), " ")
+	return strings.Trim(out.String(), " ") + lineBreak
 }
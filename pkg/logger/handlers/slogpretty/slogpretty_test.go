@@ -1,7 +1,12 @@
 package slogpretty
 
 import (
+	"errors"
+	"fmt"
 	"log/slog"
+	"love-signal-users/pkg/logger/color"
+	"love-signal-users/pkg/logger/sl"
+	"os"
 	"regexp"
 	"strings"
 	"testing"
@@ -55,3 +60,105 @@ func Test_SkipEmptyAttributes(t *testing.T) {
 		t.Errorf("exected line to be terminated with `\\n` but found `%s`", line[len(line)-1:])
 	}
 }
+
+func Test_TimeFormat(t *testing.T) {
+	cs := &captureStream{}
+	handler := NewPrettyHandler(nil, cs, WithTimeFormat("2006-01-02"))
+	logger := slog.New(handler)
+
+	logger.Info("testing logger")
+
+	lineMatcher := regexp.MustCompile(`^\d{4}-\d{2}-\d{2} INFO: testing logger`)
+	line := string(cs.lines[0])
+	if lineMatcher.MatchString(line) == false {
+		t.Errorf("expected line to start with the date but found `%s`", line)
+	}
+}
+
+func Test_AlignedKeyValues(t *testing.T) {
+	cs := &captureStream{}
+	handler := NewPrettyHandler(nil, cs, WithKeyValue(20))
+	logger := slog.New(handler)
+
+	logger.Info("short", slog.String("op", "test"), slog.Int64("user ID", 42))
+	logger.Info("longer message", slog.String("op", "test"))
+
+	first := string(cs.lines[0])
+	if !strings.Contains(first, `op=test "user ID"=42`) {
+		t.Errorf("expected sorted key=value pairs but found `%s`", first)
+	}
+	if strings.Index(first, "op=") != strings.Index(string(cs.lines[1]), "op=") {
+		t.Errorf("expected attributes to be aligned but found `%s` and `%s`", first, cs.lines[1])
+	}
+}
+
+func Test_DottedGroups(t *testing.T) {
+	cs := &captureStream{}
+	handler := NewPrettyHandler(nil, cs, WithKeyValue(0), WithDottedGroups())
+	logger := slog.New(handler).WithGroup("request").With(slog.String("id", "abc"))
+
+	logger.Info("testing logger", slog.Group("user", slog.Int64("id", 42)))
+
+	line := string(cs.lines[0])
+	if !strings.Contains(line, "request.id=abc") || !strings.Contains(line, "request.user.id=42") {
+		t.Errorf("expected dotted group keys but found `%s`", line)
+	}
+}
+
+func Test_Source(t *testing.T) {
+	cs := &captureStream{}
+	handler := NewPrettyHandler(&slog.HandlerOptions{AddSource: true}, cs)
+	logger := slog.New(handler)
+
+	logger.Info("testing logger")
+
+	lineMatcher := regexp.MustCompile(`INFO: slogpretty/slogpretty_test\.go:\d+ testing logger\n$`)
+	line := string(cs.lines[0])
+	if lineMatcher.MatchString(line) == false {
+		t.Errorf("expected source file:line before the message but found `%s`", line)
+	}
+}
+
+func Test_MultilineErrors(t *testing.T) {
+	cs := &captureStream{}
+	handler := NewPrettyHandler(nil, cs, WithMultilineErrors())
+	logger := slog.New(handler)
+
+	notFound := errors.New("entity not found")
+	err := fmt.Errorf("usecase.user.Execute: %w", fmt.Errorf("sqlite.User: %w", notFound))
+	logger.Error("testing logger", sl.Err(err))
+
+	line := string(cs.lines[0])
+	expected := "testing logger\n" +
+		"  error: usecase.user.Execute\n" +
+		"         sqlite.User\n" +
+		"         entity not found\n"
+	if !strings.HasSuffix(line, expected) {
+		t.Errorf("expected error chain on multiple lines but found `%s`", line)
+	}
+
+	// Separators inside the message of one error are not split.
+	logger.Error("testing logger", sl.Err(fmt.Errorf("sqlite.User: %w", errors.New("near \"from\": syntax error"))))
+
+	line = string(cs.lines[1])
+	expected = "testing logger\n" +
+		"  error: sqlite.User\n" +
+		"         near \"from\": syntax error\n"
+	if !strings.HasSuffix(line, expected) {
+		t.Errorf("expected error chain split only between wrapped errors but found `%s`", line)
+	}
+}
+
+func Test_AutoColorDisabledWithoutTerminal(t *testing.T) {
+	reader, writer, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer reader.Close()
+	defer writer.Close()
+
+	handler := NewPrettyHandler(nil, writer, WithAutoColor())
+	if handler.colorize(color.Cyan, "value") != "value" {
+		t.Error("expected colors to be disabled when output is not a terminal")
+	}
+}
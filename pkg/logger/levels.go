@@ -0,0 +1,69 @@
+package logger
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+)
+
+// ErrSinksChanged is returned when the levels are set for sinks other than the ones the logger was set up with.
+var ErrSinksChanged = errors.New("log sinks have changed")
+
+// Levels are the min levels of the logger sinks, which can be changed while the logger is in use.
+type Levels struct {
+	mutex sync.Mutex
+	vars  []*slog.LevelVar
+}
+
+// NewLevels returns new levels to be set up with WithLevels.
+func NewLevels() *Levels {
+	return &Levels{}
+}
+
+// Set sets the levels of the sinks in the order they were passed to Setup, with the same rules as Sink.Level.
+// Without sinks, it sets the level of the environment preset. The number of levels must match the sinks
+// the logger was set up with. No level is changed if any of them is invalid.
+func (l *Levels) Set(environment string, sinkLevels []string) error {
+	levels := []slog.Level{presetLevel(environment)}
+	if len(sinkLevels) > 0 {
+		levels = make([]slog.Level, 0, len(sinkLevels))
+		for i, sinkLevel := range sinkLevels {
+			level, err := parseSinkLevel(environment, sinkLevel)
+			if err != nil {
+				return fmt.Errorf("sink %d: %w", i, err)
+			}
+			levels = append(levels, level)
+		}
+	}
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if len(levels) != len(l.vars) {
+		return fmt.Errorf("%w: %d sinks set up, %d given", ErrSinksChanged, len(l.vars), len(levels))
+	}
+
+	for i, level := range levels {
+		l.vars[i].Set(level)
+	}
+
+	return nil
+}
+
+// add adds the level of the next sink.
+func (l *Levels) add(level slog.Level) slog.Leveler {
+	v := &slog.LevelVar{}
+	v.Set(level)
+
+	if l == nil {
+		return v
+	}
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	l.vars = append(l.vars, v)
+
+	return v
+}
@@ -1,7 +1,10 @@
 package logger
 
 import (
+	"context"
+	"go.opentelemetry.io/otel/trace"
 	"log/slog"
+	"love-signal-users/pkg/logger/handlers/sampling"
 	"love-signal-users/pkg/logger/handlers/slogpretty"
 	"os"
 )
@@ -12,16 +15,56 @@ const (
 	envProd  = "prod"
 )
 
-func SetupLogger(environment string) *slog.Logger {
+// Option is how options for the logger are set up.
+type Option func(o *options)
+
+type options struct {
+	replaceAttr func(groups []string, a slog.Attr) slog.Attr
+	sampling    []sampling.Option
+	levels      *Levels
+}
+
+// WithReplaceAttr sets up the function that rewrites attributes of all the handlers, e.g. a redaction policy.
+func WithReplaceAttr(replaceAttr func(groups []string, a slog.Attr) slog.Attr) Option {
+	return func(o *options) {
+		o.replaceAttr = replaceAttr
+	}
+}
+
+// WithSampling sets up sampling of repeated records, see sampling.Handler.
+func WithSampling(opts ...sampling.Option) Option {
+	return func(o *options) {
+		o.sampling = append(o.sampling, opts...)
+	}
+}
+
+// WithLevels sets up the levels through which the min levels of the sinks can be changed later.
+func WithLevels(levels *Levels) Option {
+	return func(o *options) {
+		o.levels = levels
+	}
+}
+
+func SetupLogger(environment string, opts ...Option) *slog.Logger {
+	o := newOptions(opts)
+
+	log := presetLogger(environment, o)
+	if o.sampling != nil {
+		log = slog.New(sampling.New(log.Handler(), o.sampling...))
+	}
+
+	return log
+}
+
+// presetLogger returns the logger of the environment preset.
+func presetLogger(environment string, o *options) *slog.Logger {
 	var log *slog.Logger
 
 	switch environment {
 	case envLocal:
-		log = setupConsolePrettyLogger(slog.LevelDebug)
-	case envDev:
-		log = setupConsoleDefaultLogger(slog.LevelDebug)
-	case envProd:
-		log = setupConsoleDefaultLogger(slog.LevelInfo)
+		log = setupConsolePrettyLogger(o.levels.add(presetLevel(environment)), o.replaceAttr)
+	case envDev, envProd:
+		log = setupConsoleDefaultLogger(o.levels.add(presetLevel(environment)), o.replaceAttr)
 	default:
 		log = slog.Default()
 	}
@@ -29,20 +72,66 @@ func SetupLogger(environment string) *slog.Logger {
 	return log
 }
 
-func setupConsolePrettyLogger(level slog.Level) *slog.Logger {
+func newOptions(opts []Option) *options {
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	return o
+}
+
+// presetLevel returns the log level of the environment preset.
+func presetLevel(environment string) slog.Level {
+	switch environment {
+	case envLocal, envDev:
+		return slog.LevelDebug
+	default:
+		return slog.LevelInfo
+	}
+}
+
+func setupConsolePrettyLogger(level slog.Leveler, replaceAttr func([]string, slog.Attr) slog.Attr) *slog.Logger {
 	opts := &slog.HandlerOptions{
-		Level: level,
+		Level:       level,
+		ReplaceAttr: replaceAttr,
 	}
 
-	handler := slogpretty.NewPrettyHandler(opts, os.Stdout, slogpretty.WithColor())
+	handler := slogpretty.NewPrettyHandler(opts, os.Stdout, slogpretty.WithAutoColor())
 
 	return slog.New(handler)
 }
 
-func setupConsoleDefaultLogger(level slog.Level) *slog.Logger {
+func setupConsoleDefaultLogger(level slog.Leveler, replaceAttr func([]string, slog.Attr) slog.Attr) *slog.Logger {
 	log := slog.New(
-		slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: level}),
+		slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: level, ReplaceAttr: replaceAttr}),
 	)
 
 	return log
 }
+
+type contextKey struct{}
+
+// ContextWithLogger returns a copy of the context with the request-scoped logger.
+func ContextWithLogger(ctx context.Context, log *slog.Logger) context.Context {
+	return context.WithValue(ctx, contextKey{}, log)
+}
+
+// FromContext returns the request-scoped logger from the context, or the fallback logger if there is none.
+func FromContext(ctx context.Context, fallback *slog.Logger) *slog.Logger {
+	if log, ok := ctx.Value(contextKey{}).(*slog.Logger); ok {
+		return log
+	}
+
+	return fallback
+}
+
+// RequestLogger returns the logger with the request ID and, if the request is traced, the trace ID.
+func RequestLogger(ctx context.Context, log *slog.Logger, requestID string) *slog.Logger {
+	attrs := []any{slog.String("request ID", requestID)}
+	if spanContext := trace.SpanContextFromContext(ctx); spanContext.HasTraceID() {
+		attrs = append(attrs, slog.String("trace ID", spanContext.TraceID().String()))
+	}
+
+	return log.With(attrs...)
+}
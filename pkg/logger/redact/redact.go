@@ -0,0 +1,218 @@
+package redact
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+const (
+	// Tag is the struct tag that marks sensitive fields: `log:"sensitive"`.
+	Tag = "log"
+	// Sensitive is the value of the Tag that marks sensitive fields.
+	Sensitive = "sensitive"
+	// Mask replaces the values of masked keys and sensitive fields.
+	Mask = "[REDACTED]"
+
+	hashPrefix = "hash:"
+	hashLength = 16
+)
+
+// Policy is a redaction policy of log attributes.
+// Its ReplaceAttr method is used as slog.HandlerOptions.ReplaceAttr of any handler.
+type Policy struct {
+	maskedKeys map[string]struct{}
+	hashedKeys map[string]struct{}
+	hashSalt   []byte
+
+	// sensitiveTypes caches whether values of the type have sensitive fields.
+	sensitiveTypes sync.Map
+}
+
+// Option is how options for the Policy are set up.
+type Option func(p *Policy)
+
+// WithMaskedKeys sets up attribute keys whose values are replaced with the Mask. Keys are case-insensitive.
+func WithMaskedKeys(keys ...string) Option {
+	return func(p *Policy) {
+		for _, key := range keys {
+			p.maskedKeys[strings.ToLower(key)] = struct{}{}
+		}
+	}
+}
+
+// WithHashedKeys sets up attribute keys whose values, e.g. identifiers, are replaced with their hash.
+// Hashes of the same value are equal, so log lines are still correlated by the identifier. Keys are case-insensitive.
+func WithHashedKeys(keys ...string) Option {
+	return func(p *Policy) {
+		for _, key := range keys {
+			p.hashedKeys[strings.ToLower(key)] = struct{}{}
+		}
+	}
+}
+
+// WithHashSalt sets up the secret salt of the hashes, so that hashes of small identifiers cannot be brute-forced.
+func WithHashSalt(salt string) Option {
+	return func(p *Policy) {
+		p.hashSalt = []byte(salt)
+	}
+}
+
+// New returns new redaction policy. Struct fields tagged as sensitive are always masked.
+func New(opts ...Option) *Policy {
+	p := &Policy{
+		maskedKeys: make(map[string]struct{}),
+		hashedKeys: make(map[string]struct{}),
+	}
+
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	return p
+}
+
+// ReplaceAttr redacts the attribute according to the policy.
+func (p *Policy) ReplaceAttr(_ []string, a slog.Attr) slog.Attr {
+	key := strings.ToLower(a.Key)
+
+	if _, ok := p.maskedKeys[key]; ok {
+		return slog.String(a.Key, Mask)
+	}
+
+	if _, ok := p.hashedKeys[key]; ok {
+		return slog.String(a.Key, p.hash(a.Value.String()))
+	}
+
+	if a.Value.Kind() == slog.KindAny {
+		if value := reflect.ValueOf(a.Value.Any()); value.IsValid() && p.hasSensitiveFields(value.Type()) {
+			return slog.Any(a.Key, p.redactValue(value))
+		}
+	}
+
+	return a
+}
+
+// Value returns the value with sensitive struct fields masked, in a form that is marshaled as the original value.
+// Values without sensitive fields are returned as is.
+func (p *Policy) Value(v any) any {
+	value := reflect.ValueOf(v)
+	if !value.IsValid() || !p.hasSensitiveFields(value.Type()) {
+		return v
+	}
+
+	return p.redactValue(value)
+}
+
+func (p *Policy) hash(value string) string {
+	mac := hmac.New(sha256.New, p.hashSalt)
+	mac.Write([]byte(value))
+
+	return hashPrefix + hex.EncodeToString(mac.Sum(nil))[:hashLength]
+}
+
+// redactValue converts the value to maps and slices with sensitive fields masked.
+func (p *Policy) redactValue(value reflect.Value) any {
+	switch value.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if value.IsNil() {
+			return nil
+		}
+
+		return p.redactValue(value.Elem())
+	case reflect.Struct:
+		if !p.hasSensitiveFields(value.Type()) {
+			return value.Interface()
+		}
+
+		fields := make(map[string]any, value.NumField())
+		for i := range value.NumField() {
+			field := value.Type().Field(i)
+			if !field.IsExported() || field.Tag.Get("json") == "-" {
+				continue
+			}
+
+			if field.Tag.Get(Tag) == Sensitive {
+				fields[fieldName(field)] = Mask
+				continue
+			}
+
+			fields[fieldName(field)] = p.redactValue(value.Field(i))
+		}
+
+		return fields
+	case reflect.Slice, reflect.Array:
+		if !p.hasSensitiveFields(value.Type()) {
+			return value.Interface()
+		}
+
+		items := make([]any, 0, value.Len())
+		for i := range value.Len() {
+			items = append(items, p.redactValue(value.Index(i)))
+		}
+
+		return items
+	case reflect.Map:
+		if !p.hasSensitiveFields(value.Type()) {
+			return value.Interface()
+		}
+
+		items := make(map[string]any, value.Len())
+		iter := value.MapRange()
+		for iter.Next() {
+			items[fmt.Sprint(iter.Key().Interface())] = p.redactValue(iter.Value())
+		}
+
+		return items
+	default:
+		return value.Interface()
+	}
+}
+
+// hasSensitiveFields reports whether values of the type have fields tagged as sensitive.
+func (p *Policy) hasSensitiveFields(t reflect.Type) bool {
+	if cached, ok := p.sensitiveTypes.Load(t); ok {
+		return cached.(bool)
+	}
+
+	// Recursive types are considered not sensitive while they are checked.
+	p.sensitiveTypes.Store(t, false)
+
+	var sensitive bool
+	switch t.Kind() {
+	case reflect.Pointer, reflect.Slice, reflect.Array, reflect.Map:
+		sensitive = p.hasSensitiveFields(t.Elem())
+	case reflect.Struct:
+		for i := range t.NumField() {
+			field := t.Field(i)
+			if !field.IsExported() {
+				continue
+			}
+
+			if field.Tag.Get(Tag) == Sensitive || p.hasSensitiveFields(field.Type) {
+				sensitive = true
+				break
+			}
+		}
+	default:
+		sensitive = false
+	}
+
+	p.sensitiveTypes.Store(t, sensitive)
+
+	return sensitive
+}
+
+// fieldName returns the name of the field as it is marshaled to JSON.
+func fieldName(field reflect.StructField) string {
+	if name, _, _ := strings.Cut(field.Tag.Get("json"), ","); name != "" {
+		return name
+	}
+
+	return field.Name
+}
@@ -0,0 +1,58 @@
+package redact
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+type credentials struct {
+	Login    string
+	Password string `log:"sensitive"`
+}
+
+type config struct {
+	Env         string
+	Credentials credentials
+	Replicas    []*credentials
+}
+
+func Test_PolicyRedactsAttributes(t *testing.T) {
+	buf := &bytes.Buffer{}
+	policy := New(WithMaskedKeys("Token"), WithHashedKeys("user ID"), WithHashSalt("salt"))
+	log := slog.New(slog.NewJSONHandler(buf, &slog.HandlerOptions{ReplaceAttr: policy.ReplaceAttr}))
+
+	log.Info(
+		"message",
+		slog.String("token", "secret-token"),
+		slog.Int64("user ID", 42),
+		slog.Any("config", config{
+			Env:         "prod",
+			Credentials: credentials{Login: "admin", Password: "secret-password"},
+			Replicas:    []*credentials{{Login: "replica", Password: "replica-password"}},
+		}),
+	)
+
+	out := buf.String()
+	for _, secret := range []string{"secret-token", "secret-password", "replica-password", `"user ID":42`} {
+		if strings.Contains(out, secret) {
+			t.Errorf("expected %q to be redacted, got: %s", secret, out)
+		}
+	}
+
+	var line map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &line); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if line["token"] != Mask {
+		t.Errorf("expected masked token, got: %v", line["token"])
+	}
+	if line["user ID"] != policy.hash("42") {
+		t.Errorf("expected hashed user ID, got: %v", line["user ID"])
+	}
+	if !strings.Contains(out, `"Login":"admin"`) || !strings.Contains(out, `"Env":"prod"`) {
+		t.Errorf("expected not sensitive fields to be kept, got: %s", out)
+	}
+}
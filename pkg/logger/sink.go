@@ -0,0 +1,129 @@
+package logger
+
+import (
+	"errors"
+	"fmt"
+	"gopkg.in/natefinch/lumberjack.v2"
+	"io"
+	"log/slog"
+	"love-signal-users/pkg/logger/handlers/fanout"
+	"love-signal-users/pkg/logger/handlers/sampling"
+	"love-signal-users/pkg/logger/handlers/slogpretty"
+	"os"
+	"strings"
+)
+
+// Sink types.
+const (
+	SinkStdoutPretty = "stdout_pretty"
+	SinkStdoutJSON   = "stdout_json"
+	SinkFile         = "file"
+)
+
+// ErrUnknownSinkType is returned when the sink type is not one of the sink types.
+var ErrUnknownSinkType = errors.New("unknown log sink type")
+
+// Sink is a destination of the logs.
+type Sink struct {
+	// Type is one of SinkStdoutPretty, SinkStdoutJSON or SinkFile.
+	Type string
+	// Level is the min level of the sink: debug, info, warn or error. The level of the environment preset by default.
+	Level string
+
+	// Path is the path to the log file. File sinks write JSON.
+	Path string
+	// MaxSizeMB is the size of the file in megabytes after which it is rotated. 100 megabytes by default.
+	MaxSizeMB int
+	// MaxAgeDays is how many days rotated files are kept. Rotated files are not removed by age by default.
+	MaxAgeDays int
+	// MaxBackups is how many rotated files are kept. All rotated files are kept by default.
+	MaxBackups int
+	// Compress enables gzip compression of rotated files.
+	Compress bool
+}
+
+// Setup returns the logger that writes to all the sinks, and the function that closes the log files.
+// Without sinks, the logger is set up by the environment preset as SetupLogger does.
+// Options apply to all the sinks.
+func Setup(environment string, sinks []Sink, opts ...Option) (*slog.Logger, func() error, error) {
+	o := newOptions(opts)
+
+	if len(sinks) == 0 {
+		return withSampling(presetLogger(environment, o).Handler(), o, func() error { return nil })
+	}
+
+	handlers := make([]slog.Handler, 0, len(sinks))
+	var files []io.Closer
+	closeFiles := func() error {
+		var errs []error
+		for _, file := range files {
+			errs = append(errs, file.Close())
+		}
+
+		return errors.Join(errs...)
+	}
+
+	for i, sink := range sinks {
+		level, err := parseSinkLevel(environment, sink.Level)
+		if err != nil {
+			_ = closeFiles()
+			return nil, nil, fmt.Errorf("sink %d: %w", i, err)
+		}
+		handlerOpts := &slog.HandlerOptions{Level: o.levels.add(level), ReplaceAttr: o.replaceAttr}
+
+		switch sink.Type {
+		case SinkStdoutPretty:
+			handlers = append(handlers, slogpretty.NewPrettyHandler(handlerOpts, os.Stdout, slogpretty.WithAutoColor()))
+		case SinkStdoutJSON:
+			handlers = append(handlers, slog.NewJSONHandler(os.Stdout, handlerOpts))
+		case SinkFile:
+			file := &lumberjack.Logger{
+				Filename:   sink.Path,
+				MaxSize:    sink.MaxSizeMB,
+				MaxAge:     sink.MaxAgeDays,
+				MaxBackups: sink.MaxBackups,
+				Compress:   sink.Compress,
+			}
+			files = append(files, file)
+			handlers = append(handlers, slog.NewJSONHandler(file, handlerOpts))
+		default:
+			_ = closeFiles()
+			return nil, nil, fmt.Errorf("sink %d: %w: %q", i, ErrUnknownSinkType, sink.Type)
+		}
+	}
+
+	return withSampling(fanout.New(handlers...), o, closeFiles)
+}
+
+// withSampling returns the logger with the handler wrapped in the sampling handler if sampling is set up.
+// The returned close function writes the summaries of suppressed records before closing the sinks.
+func withSampling(handler slog.Handler, o *options, closeSinks func() error) (*slog.Logger, func() error, error) {
+	if o.sampling == nil {
+		return slog.New(handler), closeSinks, nil
+	}
+
+	sampler := sampling.New(handler, o.sampling...)
+
+	return slog.New(sampler), func() error {
+		return errors.Join(sampler.Close(), closeSinks())
+	}, nil
+}
+
+// parseSinkLevel parses the level of the sink. Empty level is the level of the environment preset.
+func parseSinkLevel(environment, level string) (slog.Level, error) {
+	if level == "" {
+		return presetLevel(environment), nil
+	}
+
+	return ParseLevel(level)
+}
+
+// ParseLevel parses the level name: debug, info, warn or error, case-insensitive.
+func ParseLevel(level string) (slog.Level, error) {
+	var l slog.Level
+	if err := l.UnmarshalText([]byte(strings.ToUpper(level))); err != nil {
+		return 0, fmt.Errorf("invalid log level %q: %w", level, err)
+	}
+
+	return l, nil
+}
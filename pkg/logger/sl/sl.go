@@ -4,9 +4,8 @@ import (
 	"log/slog"
 )
 
+// Err returns the error attribute. The attribute holds the error itself, so that handlers can walk its chain;
+// handlers of the standard library render it as the error message.
 func Err(err error) slog.Attr {
-	return slog.Attr{
-		Key:   "error",
-		Value: slog.StringValue(err.Error()),
-	}
+	return slog.Any("error", err)
 }
@@ -0,0 +1,50 @@
+package requestid
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+)
+
+// Header is the name of the gRPC metadata key and HTTP header that carries the request ID.
+const Header = "x-request-id"
+
+// maxLength is the max length of a request ID taken from the client. Longer IDs are replaced with a new one.
+const maxLength = 128
+
+type contextKey struct{}
+
+// New returns new random request ID.
+func New() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+
+	return hex.EncodeToString(b)
+}
+
+// Ensure returns the request ID from the client if it is valid, otherwise new request ID.
+func Ensure(id string) string {
+	if id == "" || len(id) > maxLength {
+		return New()
+	}
+
+	for _, r := range id {
+		if r < 0x21 || r > 0x7e {
+			return New()
+		}
+	}
+
+	return id
+}
+
+// ContextWithRequestID returns a copy of the context with the request ID.
+func ContextWithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, contextKey{}, id)
+}
+
+// FromContext returns the request ID from the context, or empty string if there is none.
+func FromContext(ctx context.Context) string {
+	id, _ := ctx.Value(contextKey{}).(string)
+
+	return id
+}
@@ -0,0 +1,22 @@
+package requestid
+
+import (
+	"strings"
+	"testing"
+)
+
+func Test_Ensure(t *testing.T) {
+	if got := Ensure("abc-123"); got != "abc-123" {
+		t.Errorf("expected client request ID to be kept, got: %s", got)
+	}
+
+	for _, id := range []string{"", "with space", "line\nbreak", strings.Repeat("a", maxLength+1)} {
+		got := Ensure(id)
+		if got == id {
+			t.Errorf("expected invalid request ID %q to be replaced", id)
+		}
+		if len(got) != 32 {
+			t.Errorf("expected new request ID of 32 chars, got: %q", got)
+		}
+	}
+}
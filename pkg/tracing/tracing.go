@@ -0,0 +1,156 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+	"io"
+	"log/slog"
+	"os"
+)
+
+const instrumentationName = "love-signal-users"
+
+// Config is the tracing configuration.
+// Spans are exported to the OTLP endpoint if it is set, otherwise to the file, or to stdout if the file is not set.
+type Config struct {
+	ServiceName  string
+	OTLPEndpoint string
+	OTLPInsecure bool
+	File         string
+	SampleRatio  float64
+}
+
+// Setup sets up the global tracer provider and the W3C trace context propagator.
+// The returned function flushes the remaining spans and shuts down the tracer provider.
+func Setup(ctx context.Context, cfg Config) (func(context.Context) error, error) {
+	const op = "tracing.Setup"
+
+	exporter, closeOutput, err := newExporter(ctx, cfg)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	res, err := resource.Merge(
+		resource.Default(),
+		resource.NewWithAttributes(semconv.SchemaURL, semconv.ServiceName(cfg.ServiceName)),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.SampleRatio))),
+	)
+
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	return func(ctx context.Context) error {
+		if err := provider.Shutdown(ctx); err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+
+		return closeOutput()
+	}, nil
+}
+
+func newExporter(ctx context.Context, cfg Config) (sdktrace.SpanExporter, func() error, error) {
+	noClose := func() error { return nil }
+
+	if cfg.OTLPEndpoint != "" {
+		opts := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(cfg.OTLPEndpoint)}
+		if cfg.OTLPInsecure {
+			opts = append(opts, otlptracegrpc.WithInsecure())
+		}
+
+		exporter, err := otlptracegrpc.New(ctx, opts...)
+
+		return exporter, noClose, err
+	}
+
+	var out io.Writer = os.Stdout
+	closeOutput := noClose
+	if cfg.File != "" {
+		file, err := os.OpenFile(cfg.File, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		out = file
+		closeOutput = file.Close
+	}
+
+	exporter, err := stdouttrace.New(stdouttrace.WithWriter(out))
+
+	return exporter, closeOutput, err
+}
+
+// Start starts a span with the name and the attributes. Attributes are given as log attributes,
+// so that spans carry the same attributes as the log records of the operation.
+func Start(ctx context.Context, name string, attrs ...slog.Attr) (context.Context, trace.Span) {
+	return otel.Tracer(instrumentationName).Start(ctx, name, trace.WithAttributes(toAttributes(attrs)...))
+}
+
+// Finish records the error of the operation, if any, and ends the span.
+// Intended to be deferred with a pointer to the named error result.
+func Finish(span trace.Span, err *error) {
+	if err != nil && *err != nil {
+		span.RecordError(*err)
+		span.SetStatus(codes.Error, (*err).Error())
+	}
+
+	span.End()
+}
+
+// SetAttributes sets the attributes on the span, e.g. identifiers known only after the span has started.
+func SetAttributes(span trace.Span, attrs ...slog.Attr) {
+	span.SetAttributes(toAttributes(attrs)...)
+}
+
+// OperationHook starts a span for the operation with the attributes and ends it with the operation result.
+func OperationHook(ctx context.Context, op string, attrs ...slog.Attr) (context.Context, func(err error)) {
+	ctx, span := Start(ctx, op, attrs...)
+
+	return ctx, func(err error) {
+		Finish(span, &err)
+	}
+}
+
+func toAttributes(attrs []slog.Attr) []attribute.KeyValue {
+	kvs := make([]attribute.KeyValue, 0, len(attrs))
+	for _, a := range attrs {
+		v := a.Value.Resolve()
+
+		switch v.Kind() {
+		case slog.KindString:
+			kvs = append(kvs, attribute.String(a.Key, v.String()))
+		case slog.KindInt64:
+			kvs = append(kvs, attribute.Int64(a.Key, v.Int64()))
+		case slog.KindUint64:
+			kvs = append(kvs, attribute.Int64(a.Key, int64(v.Uint64())))
+		case slog.KindFloat64:
+			kvs = append(kvs, attribute.Float64(a.Key, v.Float64()))
+		case slog.KindBool:
+			kvs = append(kvs, attribute.Bool(a.Key, v.Bool()))
+		default:
+			kvs = append(kvs, attribute.String(a.Key, v.String()))
+		}
+	}
+
+	return kvs
+}